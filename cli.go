@@ -0,0 +1,65 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/varadanvk/snitch/src/logging"
+	"github.com/varadanvk/snitch/src/ui"
+)
+
+// coreOptions carries the root command's persistent --config/--backend
+// flags through to every subcommand's SnitchCore/config.Manager, so they
+// all see the same configuration regardless of how snitch was invoked.
+type coreOptions struct {
+	configPath string
+	backend    string
+}
+
+// logFormat/logLevel back the --log-format/--log-level persistent flags;
+// rootCmd's PersistentPreRunE passes them to logging.Init once Cobra has
+// parsed flags but before any subcommand runs.
+var (
+	logFormat string
+	logLevel  string
+)
+
+// Execute builds the root `snitch` command tree and runs it against
+// os.Args. With no subcommand it launches the TUI, preserving the
+// original default behavior; daemon/task/report/config/notify are all
+// subcommands instead of main() sniffing os.Args directly.
+func Execute() error {
+	opts := &coreOptions{}
+
+	rootCmd := &cobra.Command{
+		Use:   "snitch",
+		Short: "Snitch: an AI-powered productivity monitor",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logging.Init(logFormat, logLevel)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTUI(*opts)
+		},
+	}
+
+	rootCmd.PersistentFlags().StringVar(&opts.configPath, "config", "", "path to config.json (default ~/.snitch/config.json)")
+	rootCmd.PersistentFlags().StringVar(&opts.backend, "backend", "", "override the configured AI backend (groq, ollama, anthropic, openai)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log verbosity: debug, info, warn, or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log output format: text or json")
+
+	rootCmd.AddCommand(newDaemonCmd(opts))
+	rootCmd.AddCommand(newTaskCmd())
+	rootCmd.AddCommand(newReportCmd(opts))
+	rootCmd.AddCommand(newConfigCmd(opts))
+	rootCmd.AddCommand(newNotifyCmd(opts))
+
+	return rootCmd.Execute()
+}
+
+// runTUI launches the interactive TUI, the behavior of bare `snitch`.
+func runTUI(opts coreOptions) error {
+	sc := newSnitchCore(opts)
+	model := ui.NewModel(sc)
+	program := ui.NewProgram(model)
+	return ui.RunProgram(program)
+}
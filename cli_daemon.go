@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// newDaemonCmd returns `snitch daemon`: runs StartMonitoring in the
+// foreground with no TUI, plus the Unix socket server `snitch task`
+// talks to, suitable for a systemd/launchd unit.
+func newDaemonCmd(opts *coreOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "daemon",
+		Short: "Run monitoring in the foreground without the TUI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sc := newSnitchCore(*opts)
+
+			listener, err := serveTaskSocket(sc)
+			if err != nil {
+				return err
+			}
+			defer listener.Close()
+
+			sc.StartMonitoring()
+			sc.logger.Info("daemon: monitoring started", "task_socket", taskSocketPath())
+
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+			<-sigs
+
+			sc.logger.Info("daemon: received shutdown signal, stopping")
+			sc.StopMonitoring()
+			return nil
+		},
+	}
+}
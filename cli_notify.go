@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/varadanvk/snitch/src/notifications"
+)
+
+// newNotifyCmd returns `snitch notify`, whose `test` subcommand sends a
+// synthetic notification through every configured backend (those named in
+// cfg.Notifiers, plus the implicit "sms" one when AccountabilityBuddies is
+// set) and reports success/failure per backend. This is the Cobra home for what
+// used to be the standalone test_notifications.go/request_permissions.go
+// scripts.
+func newNotifyCmd(opts *coreOptions) *cobra.Command {
+	notifyCmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Manage notification destinations",
+	}
+
+	notifyCmd.AddCommand(&cobra.Command{
+		Use:   "test",
+		Short: "Send a test notification to every configured destination",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := configManagerFor(*opts).Get()
+
+			manager, err := notifications.NewManager(time.Duration(cfg.NotificationInterval)*time.Second, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to configure notification backends: %w", err)
+			}
+
+			names := manager.GetNames()
+			if len(names) == 0 {
+				fmt.Println("No notification backends configured (Notifiers is empty)")
+				return nil
+			}
+
+			fmt.Printf("Testing %d notification destination(s)...\n\n", len(names))
+			for _, result := range manager.Test() {
+				if result.Err != nil {
+					fmt.Printf("FAIL  %s: %v\n", result.Name, result.Err)
+				} else {
+					fmt.Printf("OK    %s\n", result.Name)
+				}
+			}
+			return nil
+		},
+	})
+
+	return notifyCmd
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/varadanvk/snitch/src/reports"
+)
+
+// newReportCmd returns `snitch report`: generates a reports.Report from
+// persisted activity history and renders it in the requested format,
+// superseding the old standalone `report`/`reports` os.Args handling.
+func newReportCmd(opts *coreOptions) *cobra.Command {
+	var since, format, tmplPath string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate a session report",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			sinceTime, err := reports.ParseSince(since, now)
+			if err != nil {
+				return err
+			}
+
+			sc := newSnitchCore(*opts)
+			cfg := sc.GetConfig()
+			if format != "" {
+				cfg.ReportsFormat = format
+			}
+			if tmplPath != "" {
+				if cfg.ReportsFormat == "html" {
+					cfg.ReportsHTMLTemplatePath = tmplPath
+				} else {
+					cfg.ReportsMarkdownTemplatePath = tmplPath
+				}
+			}
+
+			r := sc.narrateSessionReport(sc.GenerateSessionReport(sinceTime, now))
+			formatter, err := reports.NewFormatter(cfg.ReportsFormat, cfg)
+			if err != nil {
+				return err
+			}
+
+			body, err := formatter.Format(r)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(body)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "9am", `how far back to report, e.g. "9am" or "2h"`)
+	cmd.Flags().StringVar(&format, "format", "", "output format: markdown, json, html, or porcelain (default from config)")
+	cmd.Flags().StringVar(&tmplPath, "template", "", "path to a template overriding the embedded default for --format")
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// newTaskCmd returns `snitch task`, whose get/set subcommands talk to a
+// running `snitch daemon` over its Unix socket rather than touching
+// config or activity state directly, so they work against whatever
+// daemon process actually owns the session.
+func newTaskCmd() *cobra.Command {
+	taskCmd := &cobra.Command{
+		Use:   "task",
+		Short: "Get or set the current task on a running daemon",
+	}
+
+	taskCmd.AddCommand(&cobra.Command{
+		Use:   "get",
+		Short: "Print the current task",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, err := dialTaskSocket("GET")
+			if err != nil {
+				return err
+			}
+			fmt.Println(task)
+			return nil
+		},
+	})
+
+	taskCmd.AddCommand(&cobra.Command{
+		Use:   "set <text>",
+		Short: "Set the current task",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := dialTaskSocket("SET " + strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			if resp != "OK" {
+				return fmt.Errorf("daemon: %s", resp)
+			}
+			fmt.Println("Task updated")
+			return nil
+		},
+	})
+
+	return taskCmd
+}
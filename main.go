@@ -1,8 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"math/rand"
 	"os"
 	"sync"
@@ -10,11 +11,19 @@ import (
 
 	"github.com/varadanvk/snitch/src/config"
 	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/cluster"
+	"github.com/varadanvk/snitch/src/core/store"
+	"github.com/varadanvk/snitch/src/logging"
 	"github.com/varadanvk/snitch/src/ml"
 	"github.com/varadanvk/snitch/src/notifications"
-	"github.com/varadanvk/snitch/src/ui"
+	"github.com/varadanvk/snitch/src/reports"
 )
 
+// snitchModeThreshold is the productivity score below which, in snitch
+// mode, an activity is escalated to a notification even though its score
+// alone wouldn't normally cross the !IsProductive bar.
+const snitchModeThreshold = 0.3
+
 const Version = "v1.0.0"
 
 // SnitchCore manages the core monitoring functionality
@@ -22,40 +31,98 @@ type SnitchCore struct {
 	configManager       *config.Manager
 	screenMonitor       *core.ScreenMonitor
 	activityHistory     *core.ActivityHistory
-	analyzer            *ml.Analyzer
+	activityStore       core.ActivityStore
+	sessionStore        core.SessionStore
+	clusterer           *cluster.Clusterer
+	analyzer            *ml.AIAnalyzer
 	notificationManager *notifications.Manager
 
 	currentTask    string
 	isMonitoring   bool
 	monitoringStop chan bool
 	sessionStart   time.Time
+	sessionID      string
+	lastReportDay  int
 	mu             sync.RWMutex
+
+	// analysisSubs holds one channel per open SubscribeAnalysis call (the
+	// TUI's live analysis view); monitoringLoop broadcasts every
+	// ml.AnalysisEvent from the analyzer to all of them.
+	analysisSubs []chan ml.AnalysisEvent
+	analysisMu   sync.Mutex
+
+	logger *slog.Logger
 }
 
-// NewSnitchCore creates a new Snitch core instance
-func NewSnitchCore() *SnitchCore {
-	configManager := config.NewManager()
+// newSnitchCore creates a new Snitch core instance, honoring the root
+// command's --config/--backend persistent flags via opts so every
+// subcommand (daemon, report, the TUI, ...) sees the same configuration
+// regardless of how snitch was invoked.
+func newSnitchCore(opts coreOptions) *SnitchCore {
+	configManager := configManagerFor(opts)
 	cfg := configManager.Get()
+	if opts.backend != "" {
+		cfg.AIBackend = opts.backend
+		cfg.BackendChain = append([]string{opts.backend}, cfg.BackendChain...)
+	}
 
-	// Determine AI backend
-	var backend ml.AIBackendType
-	switch cfg.AIBackend {
-	case "groq":
-		backend = ml.BackendGroq
-	case "ollama":
-		backend = ml.BackendOllama
-	default:
-		backend = ml.BackendGroq // Default to Groq
+	logger := logging.With("core")
+
+	analyzer := ml.NewAnalyzerFromConfig(cfg)
+
+	activityHistory := core.NewActivityHistory()
+	activityStore, err := newActivityStore(cfg)
+	if err != nil {
+		logger.Warn("store: failed to open activity store, history won't persist across restarts", "error", err)
 	}
 
-	return &SnitchCore{
+	sessionStore, err := store.NewSQLiteSessionStore(cfg.SQLitePath)
+	if err != nil {
+		logger.Warn("store: failed to open session store, session history won't persist across restarts", "error", err)
+	}
+
+	notificationManager, err := notifications.NewManager(time.Duration(cfg.NotificationInterval)*time.Second, cfg)
+	if err != nil {
+		logger.Warn("notifications: failed to configure notifiers, falling back to desktop only", "error", err)
+		cfg.Notifiers = []string{"desktop"}
+		notificationManager, _ = notifications.NewManager(time.Duration(cfg.NotificationInterval)*time.Second, cfg)
+	}
+	logger.Info("notifications: enabled backends", "backends", notificationManager.GetNames())
+
+	sc := &SnitchCore{
 		configManager:       configManager,
 		screenMonitor:       core.NewScreenMonitor(),
-		activityHistory:     core.NewActivityHistory(),
-		analyzer:            ml.NewAnalyzer(backend, cfg.OllamaURL, cfg.OllamaModel, cfg.GroqAPIKey),
-		notificationManager: notifications.NewManager(time.Duration(cfg.NotificationInterval) * time.Second),
+		activityHistory:     activityHistory,
+		activityStore:       activityStore,
+		sessionStore:        sessionStore,
+		clusterer:           cluster.NewClusterer(0),
+		analyzer:            analyzer,
+		notificationManager: notificationManager,
 		monitoringStop:      make(chan bool),
 		sessionStart:        time.Now(),
+		sessionID:           fmt.Sprintf("%d", time.Now().UnixNano()),
+		logger:              logger,
+	}
+
+	analyzer.ConfigureAgents(ml.AgentContext{
+		History: activityHistory,
+		GetTask: sc.GetCurrentTask,
+	})
+	if err := analyzer.SelectAgent(cfg.ScreenshotAgent); err != nil {
+		logger.Warn("agent: failed to select screenshot agent", "agent", cfg.ScreenshotAgent, "error", err)
+	}
+
+	return sc
+}
+
+// newActivityStore builds the core.ActivityStore selected by
+// cfg.ActivityStoreBackend ("sqlite", the default, or "clickhouse").
+func newActivityStore(cfg *config.Config) (core.ActivityStore, error) {
+	switch cfg.ActivityStoreBackend {
+	case "clickhouse":
+		return store.NewClickHouseStore(cfg.ClickHouseDSN, cfg.ActivityRetentionDays)
+	default:
+		return store.NewSQLiteStore(cfg.SQLitePath, cfg.ActivityRetentionDays)
 	}
 }
 
@@ -68,6 +135,7 @@ func (sc *SnitchCore) StartMonitoring() {
 	}
 	sc.isMonitoring = true
 	sc.sessionStart = time.Now()
+	sc.sessionID = fmt.Sprintf("%d", time.Now().UnixNano())
 	sc.mu.Unlock()
 
 	go sc.monitoringLoop()
@@ -84,6 +152,53 @@ func (sc *SnitchCore) StopMonitoring() {
 	sc.mu.Unlock()
 
 	sc.monitoringStop <- true
+
+	if _, err := sc.SendSessionReport(sc.sessionStart, time.Now()); err != nil {
+		sc.logger.Warn("reports: failed to send end-of-session report", "session_id", sc.sessionID, "error", err)
+	}
+
+	sc.saveSessionSnapshot(sc.sessionStart, time.Now())
+}
+
+// saveSessionSnapshot persists a core.Session summarizing [start, end] to
+// sessionStore, for the TUI's Session History view. No-op if no session
+// store is available (e.g. it failed to open at startup).
+func (sc *SnitchCore) saveSessionSnapshot(start, end time.Time) {
+	if sc.sessionStore == nil {
+		return
+	}
+
+	r := sc.GenerateSessionReport(start, end)
+	total := r.ProductiveSeconds + r.DistractingSeconds
+	rate := 0.0
+	if total > 0 {
+		rate = float64(r.ProductiveSeconds) / float64(total)
+	}
+
+	topApps := make([]string, 0, 3)
+	for i, bucket := range r.AppBuckets {
+		if i >= 3 {
+			break
+		}
+		topApps = append(topApps, bucket.Application)
+	}
+
+	session := core.Session{
+		ID:               sc.sessionID,
+		Start:            start,
+		End:              end,
+		Task:             sc.GetCurrentTask(),
+		ProductivityRate: rate,
+		TopApps:          topApps,
+	}
+	if tokenStats, ok := sc.analyzer.GroqTokenStats(); ok {
+		session.PromptTokens = tokenStats.PromptTokens
+		session.CompletionTokens = tokenStats.CompletionTokens
+		session.TotalTokens = tokenStats.TotalTokens
+	}
+	if err := sc.sessionStore.Add(session); err != nil {
+		sc.logger.Warn("sessions: failed to persist session snapshot", "session_id", sc.sessionID, "error", err)
+	}
 }
 
 // monitoringLoop is the main monitoring loop
@@ -92,46 +207,116 @@ func (sc *SnitchCore) monitoringLoop() {
 	ticker := time.NewTicker(time.Duration(cfg.MonitoringInterval) * time.Second)
 	defer ticker.Stop()
 
-	log.Println("Monitoring loop started")
+	sc.logger.Info("monitoring loop started", "session_id", sc.sessionID, "interval_s", cfg.MonitoringInterval)
 
 	for {
 		select {
 		case <-sc.monitoringStop:
-			log.Println("Monitoring loop stopped")
+			sc.logger.Info("monitoring loop stopped", "session_id", sc.sessionID)
 			return
 		case <-ticker.C:
+			// Refresh the CalDAV calendar so this cycle's analysis and task
+			// display reflect the user's current schedule.
+			if cal := sc.analyzer.Calendar(); cal != nil {
+				if err := cal.Refresh(context.Background()); err != nil {
+					sc.logger.Warn("caldav: failed to refresh calendar", "session_id", sc.sessionID, "error", err)
+				}
+			}
+
 			// Capture screen
 			img, err := sc.screenMonitor.CaptureScreen()
 			if err != nil {
-				log.Printf("Error capturing screen: %v", err)
+				sc.logger.Warn("failed to capture screen", "session_id", sc.sessionID, "error", err)
 				continue
 			}
 
 			// Get window info
 			windowInfo, err := sc.screenMonitor.GetActiveWindow()
 			if err != nil {
-				log.Printf("Error getting window info: %v", err)
+				sc.logger.Warn("failed to get window info", "session_id", sc.sessionID, "error", err)
 				continue
 			}
 
-			// Analyze activity
-			activity, err := sc.analyzer.AnalyzeActivity(img, windowInfo, cfg.MonitoringInterval, sc.GetCurrentTask())
+			// Analyze activity, streaming incremental deltas to any
+			// subscribed live analysis view (publishAnalysisEvent is a
+			// no-op with none) while still blocking for the final Activity
+			// the rest of this loop needs.
+			activity, err := sc.analyzer.AnalyzeActivityStream(img, windowInfo, cfg.MonitoringInterval, sc.GetCurrentTask(), sc.publishAnalysisEvent)
 			if err != nil {
-				log.Printf("Error analyzing activity: %v", err)
+				sc.logger.Warn("failed to analyze activity", "session_id", sc.sessionID, "app", windowInfo.Application, "window_title", windowInfo.Title, "error", err)
 				continue
 			}
 
+			sc.logger.Debug("activity classified",
+				"session_id", sc.sessionID,
+				"backend", activity.Source,
+				"app", activity.Application,
+				"window_title", activity.WindowTitle,
+				"productive", activity.IsProductive,
+				"score", activity.ProductivityScore,
+				"interval_s", cfg.MonitoringInterval,
+			)
+
+			// Embed and cluster the activity so recurring activity types can
+			// be surfaced in the Clusters view without hand-labeling.
+			embedding, err := sc.analyzer.Embed(activity.Activity + " " + activity.WindowTitle)
+			if err != nil {
+				sc.logger.Debug("cluster: failed to embed activity", "session_id", sc.sessionID, "error", err)
+			} else {
+				activity.Embedding = embedding
+			}
+			sc.clusterer.Assign(activity)
+
 			// Add to history
 			sc.activityHistory.Add(activity)
+			if sc.activityStore != nil {
+				if err := sc.activityStore.Add(activity); err != nil {
+					sc.logger.Warn("store: failed to persist activity", "session_id", sc.sessionID, "error", err)
+				}
+			}
 
-			// Send notification if appropriate
-			if !activity.IsProductive {
+			// Send notification if appropriate. In snitch mode, also escalate
+			// any activity whose score crosses snitchModeThreshold even if
+			// it was classified productive.
+			shouldNotify := !activity.IsProductive
+			if cfg.SnitchMode && activity.ProductivityScore < snitchModeThreshold {
+				shouldNotify = true
+			}
+			if shouldNotify {
 				sc.notificationManager.SendActivityNotification(activity)
 			}
+
+			sc.maybeSendScheduledReport(cfg)
 		}
 	}
 }
 
+// maybeSendScheduledReport auto-sends a report covering the current session
+// once per day at cfg.ReportCronHour, skipping entirely when the hour is
+// disabled (outside [0,23]) or already sent today.
+func (sc *SnitchCore) maybeSendScheduledReport(cfg *config.Config) {
+	if cfg.ReportCronHour < 0 || cfg.ReportCronHour > 23 {
+		return
+	}
+
+	now := time.Now()
+	if now.Hour() != cfg.ReportCronHour {
+		return
+	}
+
+	sc.mu.Lock()
+	if sc.lastReportDay == now.YearDay() {
+		sc.mu.Unlock()
+		return
+	}
+	sc.lastReportDay = now.YearDay()
+	sc.mu.Unlock()
+
+	if _, err := sc.SendSessionReport(sc.sessionStart, now); err != nil {
+		sc.logger.Warn("reports: failed to send scheduled report", "session_id", sc.sessionID, "error", err)
+	}
+}
+
 // IsMonitoring returns whether monitoring is active
 func (sc *SnitchCore) IsMonitoring() bool {
 	sc.mu.RLock()
@@ -149,19 +334,196 @@ func (sc *SnitchCore) GetRecentActivities(count int) []core.Activity {
 	return sc.activityHistory.GetRecent(count)
 }
 
+// QueryTimeline returns [start, end) bucketed into fixed-width windows of
+// size bucket, after applying filter, from the persistent activity store.
+// Returns an error if no activity store is available (e.g. it failed to
+// open at startup).
+func (sc *SnitchCore) QueryTimeline(start, end time.Time, bucket time.Duration, filter core.Filter) (core.Timeline, error) {
+	if sc.activityStore == nil {
+		return core.Timeline{}, fmt.Errorf("no activity store configured")
+	}
+	return sc.activityStore.Aggregate(start, end, bucket, filter)
+}
+
+// GetClusters returns every activity cluster discovered so far, most
+// populous first.
+func (sc *SnitchCore) GetClusters() []cluster.Cluster {
+	return sc.clusterer.Clusters()
+}
+
+// GetSessions returns past monitoring sessions, most-recent-first, for the
+// TUI's Session History view. limit <= 0 means no cap. Returns nil if no
+// session store is available (e.g. it failed to open at startup).
+func (sc *SnitchCore) GetSessions(limit int) []core.Session {
+	if sc.sessionStore == nil {
+		return nil
+	}
+	sessions, err := sc.sessionStore.List(limit)
+	if err != nil {
+		sc.logger.Warn("sessions: failed to list sessions", "error", err)
+		return nil
+	}
+	return sessions
+}
+
+// GetActivitiesInRange returns every activity in [start, end) for a
+// session's drill-down view, sourced from the persistent activity store
+// when available and falling back to the capped in-memory history
+// otherwise.
+func (sc *SnitchCore) GetActivitiesInRange(start, end time.Time) []core.Activity {
+	if sc.activityStore != nil {
+		if activities, err := sc.activityStore.Query(start, end, core.Filter{}); err == nil {
+			return activities
+		}
+	}
+
+	var result []core.Activity
+	for _, activity := range sc.activityHistory.GetAll() {
+		if !activity.Timestamp.Before(start) && activity.Timestamp.Before(end) {
+			result = append(result, activity)
+		}
+	}
+	return result
+}
+
+// GenerateSessionReport builds a reports.Report covering [since, until]
+// from the session's activity history.
+func (sc *SnitchCore) GenerateSessionReport(since, until time.Time) reports.Report {
+	return reports.Generate(sc.activityHistory, since, until)
+}
+
+// narrateSessionReport fills r.Narrative with an LLM-generated summary from
+// the configured ReportAgent when the AI backend is available, returning r
+// unchanged (aside from Narrative) on any failure.
+func (sc *SnitchCore) narrateSessionReport(r reports.Report) reports.Report {
+	cfg := sc.configManager.Get()
+
+	prompt := fmt.Sprintf(
+		"Summarize this work session in 2-3 sentences: %d seconds productive, %d seconds distracting, top app %s.",
+		r.ProductiveSeconds, r.DistractingSeconds, topApp(r),
+	)
+	narrative, err := sc.analyzer.RunReportAgent(context.Background(), cfg.ReportAgent, prompt)
+	if err != nil {
+		sc.logger.Warn("reports: failed to generate narrative", "agent", cfg.ReportAgent, "error", err)
+		return r
+	}
+	r.Narrative = narrative
+	return r
+}
+
+// topApp returns the top application in a report, or "nothing" if empty.
+func topApp(r reports.Report) string {
+	if len(r.AppBuckets) == 0 {
+		return "nothing"
+	}
+	return r.AppBuckets[0].Application
+}
+
+// SendSessionReport renders a reports.Report for [since, until] in
+// cfg.ReportsFormat and, when cfg.ReportsAutoNotify is set, also sends it
+// through the notifications.Manager backends activity notifications use.
+// It returns the rendered body either way, so callers (e.g. `snitch
+// report`) can also print it; whether it is sent depends only on
+// cfg.ReportsAutoNotify, not on the caller.
+func (sc *SnitchCore) SendSessionReport(since, until time.Time) (string, error) {
+	cfg := sc.configManager.Get()
+	r := sc.narrateSessionReport(sc.GenerateSessionReport(since, until))
+
+	formatter, err := reports.NewFormatter(cfg.ReportsFormat, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := formatter.Format(r)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.ReportsAutoNotify {
+		if err := sc.notificationManager.SendText("Snitch Session Report", body); err != nil {
+			sc.logger.Warn("reports: failed to auto-notify session report", "error", err)
+		}
+	}
+
+	return body, nil
+}
+
+// GetTokenStats returns cumulative Groq token usage for the current
+// session, if the Groq backend is active.
+func (sc *SnitchCore) GetTokenStats() (ml.TokenStats, bool) {
+	return sc.analyzer.GroqTokenStats()
+}
+
+// SubscribeAnalysis registers a new listener for the ml.AnalysisEvent
+// stream monitoringLoop publishes during each analysis cycle, enabling
+// Groq streaming (a no-op for other backends) so the first subscriber
+// actually starts seeing deltas instead of a single end-of-cycle event.
+// It returns the event channel plus an unsubscribe func the caller must
+// invoke once it stops reading (e.g. the TUI leaving the live analysis
+// view) so publishAnalysisEvent drops the channel instead of holding it,
+// and its buffer, forever in a long-running daemon.
+func (sc *SnitchCore) SubscribeAnalysis() (<-chan ml.AnalysisEvent, func()) {
+	sc.analyzer.SetStreaming(true)
+
+	ch := make(chan ml.AnalysisEvent, 64)
+	sc.analysisMu.Lock()
+	sc.analysisSubs = append(sc.analysisSubs, ch)
+	sc.analysisMu.Unlock()
+
+	unsubscribe := func() {
+		sc.analysisMu.Lock()
+		defer sc.analysisMu.Unlock()
+		for i, sub := range sc.analysisSubs {
+			if sub == ch {
+				sc.analysisSubs = append(sc.analysisSubs[:i], sc.analysisSubs[i+1:]...)
+				break
+			}
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publishAnalysisEvent broadcasts event to every channel registered via
+// SubscribeAnalysis, dropping it for any subscriber whose buffer is full
+// rather than blocking the monitoring loop on a slow reader.
+func (sc *SnitchCore) publishAnalysisEvent(event ml.AnalysisEvent) {
+	sc.analysisMu.Lock()
+	subs := sc.analysisSubs
+	sc.analysisMu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
 // SetCurrentTask sets the current task
 func (sc *SnitchCore) SetCurrentTask(task string) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 	sc.currentTask = task
-	log.Printf("Current task set to: %s", task)
+	sc.logger.Info("current task set", "session_id", sc.sessionID, "task", task)
 }
 
-// GetCurrentTask returns the current task
+// GetCurrentTask returns the current task. When no task has been set
+// manually, it falls back to whatever the configured CalDAV calendar says
+// the user is scheduled to be doing right now, so the TUI's task display
+// auto-populates from meetings/events instead of sitting blank.
 func (sc *SnitchCore) GetCurrentTask() string {
 	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.currentTask
+	task := sc.currentTask
+	sc.mu.RUnlock()
+
+	if task != "" {
+		return task
+	}
+
+	if cal := sc.analyzer.Calendar(); cal != nil {
+		return cal.GetTaskForNow()
+	}
+	return ""
 }
 
 // GetConfig returns the current configuration
@@ -178,16 +540,8 @@ func main() {
 	// Seed random number generator
 	rand.Seed(time.Now().UnixNano())
 
-	// Create the core application instance
-	core := NewSnitchCore()
-
-	// Create the UI model with the core
-	model := ui.NewModel(core)
-
-	// Create and run the TUI program
-	program := ui.NewProgram(model)
-	if err := ui.RunProgram(program); err != nil {
-		fmt.Printf("Error: %v", err)
+	if err := Execute(); err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// taskSocketPath returns the Unix socket `snitch daemon` listens on and
+// `snitch task` dials: $XDG_RUNTIME_DIR/snitch.sock, falling back to the
+// system temp dir when XDG_RUNTIME_DIR isn't set (e.g. on macOS).
+func taskSocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "snitch.sock")
+}
+
+// serveTaskSocket listens on taskSocketPath() and answers "GET" (replies
+// with the current task) and "SET <text>" (updates it, replies "OK")
+// requests from `snitch task` until the returned listener is closed.
+func serveTaskSocket(sc *SnitchCore) (net.Listener, error) {
+	path := taskSocketPath()
+	os.Remove(path) // clear a stale socket left behind by a crashed daemon
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("socket: failed to listen on %q: %w", path, err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return // listener closed
+			}
+			go handleTaskConn(conn, sc)
+		}
+	}()
+
+	return listener, nil
+}
+
+// handleTaskConn answers a single task request and closes the connection;
+// `snitch task get`/`set` are one-shot clients, not a persistent session.
+func handleTaskConn(conn net.Conn, sc *SnitchCore) {
+	defer conn.Close()
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+	line = strings.TrimSpace(line)
+
+	switch {
+	case line == "GET":
+		fmt.Fprintln(conn, sc.GetCurrentTask())
+	case strings.HasPrefix(line, "SET "):
+		sc.SetCurrentTask(strings.TrimPrefix(line, "SET "))
+		fmt.Fprintln(conn, "OK")
+	default:
+		fmt.Fprintf(conn, "ERR unknown command %q\n", line)
+	}
+}
+
+// dialTaskSocket sends a single line request to a running daemon's task
+// socket and returns its one-line response, trimmed.
+func dialTaskSocket(request string) (string, error) {
+	path := taskSocketPath()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return "", fmt.Errorf("no running `snitch daemon` found at %q: %w", path, err)
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintln(conn, request); err != nil {
+		return "", fmt.Errorf("socket: failed to send request: %w", err)
+	}
+
+	resp, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("socket: failed to read response: %w", err)
+	}
+	return strings.TrimSpace(resp), nil
+}
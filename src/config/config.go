@@ -4,32 +4,119 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config holds all application configuration
 type Config struct {
-	MonitoringInterval    int                   `json:"monitoring_interval"`
-	NotificationInterval  int                   `json:"notification_interval"`
-	Sensitivity           string                `json:"sensitivity"`
-	FocusedHours          map[string]int        `json:"focused_hours"`
-	Theme                 string                `json:"theme"`
-	SaveScreenshots       bool                  `json:"save_screenshots"`
-	ProductiveApps        []string              `json:"productive_apps"`
-	DistractingApps       []string              `json:"distracting_apps"`
-	SnitchMode            bool                  `json:"snitch_mode"`
-	AccountabilityBuddies []AccountabilityBuddy `json:"accountability_buddies"`
-	
+	MonitoringInterval   int            `json:"monitoring_interval"`
+	NotificationInterval int            `json:"notification_interval"`
+	Sensitivity          string         `json:"sensitivity"`
+	FocusedHours         map[string]int `json:"focused_hours"`
+	Theme                string         `json:"theme"`
+	SaveScreenshots      bool           `json:"save_screenshots"`
+	ProductiveApps       []string       `json:"productive_apps"`
+	DistractingApps      []string       `json:"distracting_apps"`
+	SnitchMode           bool           `json:"snitch_mode"`
+
 	// AI Configuration
-	AIBackend   string `json:"ai_backend"`    // "ollama" or "groq"
-	OllamaURL   string `json:"ollama_url"`
-	OllamaModel string `json:"ollama_model"`
-	GroqAPIKey  string `json:"groq_api_key"`
-}
+	AIBackend       string `json:"ai_backend"` // "ollama", "groq", "anthropic" or "openai"
+	OllamaURL       string `json:"ollama_url"`
+	OllamaModel     string `json:"ollama_model"`
+	GroqAPIKey      string `json:"groq_api_key"`
+	AnthropicAPIKey string `json:"anthropic_api_key"`
+	AnthropicModel  string `json:"anthropic_model"`
+	OpenAIAPIKey    string `json:"openai_api_key"`
+	OpenAIModel     string `json:"openai_model"`
+
+	// BackendChain lists AI backends ("groq", "ollama", "anthropic",
+	// "openai") in the order they should be tried for each screenshot
+	// analysis: AIAnalyzer.AnalyzeActivity tries them in sequence, retrying
+	// each up to AIBackendRetries times before moving to the next, and only
+	// falls back to heuristic analysis once every backend in the chain has
+	// failed. Defaults to AIBackend alone when empty, preserving the old
+	// single-backend behavior.
+	BackendChain     []string      `json:"backend_chain"`
+	AIBackendRetries int           `json:"ai_backend_retries"`
+	AIBackendTimeout time.Duration `json:"ai_backend_timeout"`
+
+	// ScreenshotAgent selects which agent ("classifier", "coach", "auditor")
+	// analyzes each monitored screenshot. ReportAgent selects which agent
+	// runs for scheduled/on-demand reports.
+	ScreenshotAgent string `json:"screenshot_agent"`
+	ReportAgent     string `json:"report_agent"`
+
+	// ReportCronHour, when in [0, 23], auto-sends a session report once a
+	// day at that local hour via the configured notification backends, in
+	// addition to the report sent when monitoring stops. -1 disables the
+	// scheduled report.
+	ReportCronHour int `json:"report_cron_hour"`
+
+	// CalDAV settings. When CalDAVURL is set, Analyzer builds a
+	// caldav.Calendar so AnalyzeScreenshot can include the user's current
+	// scheduled event as prompt context and the TUI can auto-populate the
+	// current task from it.
+	CalDAVURL      string `json:"caldav_url"`
+	CalDAVUsername string `json:"caldav_username"`
+	CalDAVPassword string `json:"caldav_password"`
+
+	// ActivityStoreBackend selects the core.ActivityStore persisting
+	// activities beyond ActivityHistory's capped in-memory window: "sqlite"
+	// (default) or "clickhouse". SQLitePath/ClickHouseDSN configure whichever
+	// backend is selected.
+	ActivityStoreBackend string `json:"activity_store_backend"`
+	SQLitePath           string `json:"sqlite_path"`
+	ClickHouseDSN        string `json:"clickhouse_dsn"`
+
+	// ActivityRetentionDays is how long raw activity rows are kept before
+	// being pruned; with the SQLite backend, bucketed aggregates are kept
+	// forever regardless (ClickHouse has no separate aggregate table). <= 0
+	// disables pruning and keeps raw rows forever too.
+	ActivityRetentionDays int `json:"activity_retention_days"`
+
+	// Notifiers lists the notifications.Notifier backends
+	// (notifications.Manager builds them into a MultiNotifier) that every
+	// activity notification and end-of-session report is fanned out to,
+	// e.g. "desktop", "webhook", "slack", "discord", "smtp", "pushover",
+	// "telegram", "sms". Defaults to ["desktop"].
+	Notifiers         []string `json:"notifiers"`
+	WebhookURL        string   `json:"webhook_url"`
+	SlackWebhookURL   string   `json:"slack_webhook_url"`
+	DiscordWebhookURL string   `json:"discord_webhook_url"`
+	SMTPHost          string   `json:"smtp_host"`
+	SMTPUser          string   `json:"smtp_user"`
+	SMTPPassword      string   `json:"smtp_password"`
+	SMTPTo            string   `json:"smtp_to"`
+	PushoverToken     string   `json:"pushover_token"`
+	PushoverUser      string   `json:"pushover_user"`
+	TelegramBotToken  string   `json:"telegram_bot_token"`
+	TelegramChatID    string   `json:"telegram_chat_id"`
+	TwilioSID         string   `json:"twilio_sid"`
+	TwilioAuthToken   string   `json:"twilio_auth_token"`
+	TwilioFrom        string   `json:"twilio_from"`
 
-type AccountabilityBuddy struct {
-	Name        string `json:"name"`
-	PhoneNumber string `json:"phone_number"`
-	Enabled     bool   `json:"enabled"`
+	// AccountabilityBuddies lists the phone numbers the "sms" backend texts
+	// whenever it's enabled; they are its only recipients, and TwilioFrom is
+	// the sending number. NewMultiNotifier enables "sms" automatically when this is
+	// non-empty, even if "sms" isn't listed in Notifiers, so configuring a
+	// buddy is enough on its own.
+	AccountabilityBuddies []string `json:"accountability_buddies"`
+
+	// ReportsFormat selects the reports.Formatter used by `snitch report`
+	// and for auto-sent end-of-session reports: "markdown" (default),
+	// "json", "html", or "porcelain".
+	ReportsFormat string `json:"reports_format"`
+
+	// ReportsMarkdownTemplatePath / ReportsHTMLTemplatePath override the
+	// reports package's embedded default reports/templates/*.tmpl, letting
+	// users reskin reports without recompiling.
+	ReportsMarkdownTemplatePath string `json:"reports_markdown_template_path"`
+	ReportsHTMLTemplatePath     string `json:"reports_html_template_path"`
+
+	// ReportsAutoNotify, when true, sends the end-of-session reports.Report
+	// through notifications.Manager's configured backends in addition to
+	// printing it, reusing the same backends activity notifications go to.
+	ReportsAutoNotify bool `json:"reports_auto_notify"`
 }
 
 // Manager handles configuration loading/saving
@@ -61,30 +148,100 @@ func NewManager() *Manager {
 // defaultConfig returns default configuration
 func defaultConfig() *Config {
 	return &Config{
-		MonitoringInterval:    3,
-		NotificationInterval:  15,
-		Sensitivity:           "medium",
-		FocusedHours:          map[string]int{"start": 9, "end": 17},
-		Theme:                 "system",
-		SaveScreenshots:       false,
-		ProductiveApps:        []string{"Code", "Terminal", "Xcode", "IntelliJ", "Sublime Text", "Vim"},
-		DistractingApps:       []string{"Safari", "Chrome", "YouTube", "Twitter", "Instagram", "TikTok"},
-		SnitchMode:            false,
-		AccountabilityBuddies: []AccountabilityBuddy{},
-		
+		MonitoringInterval:   3,
+		NotificationInterval: 15,
+		Sensitivity:          "medium",
+		FocusedHours:         map[string]int{"start": 9, "end": 17},
+		Theme:                "system",
+		SaveScreenshots:      false,
+		ProductiveApps:       []string{"Code", "Terminal", "Xcode", "IntelliJ", "Sublime Text", "Vim"},
+		DistractingApps:      []string{"Safari", "Chrome", "YouTube", "Twitter", "Instagram", "TikTok"},
+		SnitchMode:           false,
+
 		// AI defaults
-		AIBackend:   "groq",
-		OllamaURL:   "http://localhost:11434",
-		OllamaModel: "llava",
-		GroqAPIKey:  "", // User needs to set this
+		AIBackend:       "groq",
+		OllamaURL:       "http://localhost:11434",
+		OllamaModel:     "llava",
+		GroqAPIKey:      "", // User needs to set this
+		AnthropicAPIKey: "",
+		AnthropicModel:  "claude-3-5-sonnet-20241022",
+		OpenAIAPIKey:    "",
+		OpenAIModel:     "gpt-4o-mini",
+
+		BackendChain:     []string{"groq", "ollama"},
+		AIBackendRetries: 1,
+		AIBackendTimeout: 20 * time.Second,
+
+		ScreenshotAgent: "classifier",
+		ReportAgent:     "auditor",
+
+		ReportCronHour: -1,
+
+		CalDAVURL:      "",
+		CalDAVUsername: "",
+		CalDAVPassword: "",
+
+		ActivityStoreBackend:  "sqlite",
+		SQLitePath:            filepath.Join(configDir(), "activities.db"),
+		ClickHouseDSN:         "",
+		ActivityRetentionDays: 30,
+
+		Notifiers:             []string{"desktop"},
+		WebhookURL:            "",
+		SlackWebhookURL:       "",
+		DiscordWebhookURL:     "",
+		SMTPHost:              "",
+		SMTPUser:              "",
+		SMTPPassword:          "",
+		SMTPTo:                "",
+		PushoverToken:         "",
+		PushoverUser:          "",
+		TelegramBotToken:      "",
+		TelegramChatID:        "",
+		TwilioSID:             "",
+		TwilioAuthToken:       "",
+		TwilioFrom:            "",
+		AccountabilityBuddies: nil,
+
+		ReportsFormat:               "markdown",
+		ReportsMarkdownTemplatePath: "",
+		ReportsHTMLTemplatePath:     "",
+		ReportsAutoNotify:           false,
 	}
 }
 
+// configDir returns ~/.snitch, the directory NewManager also keeps
+// config.json in, so SQLitePath's default lives alongside it.
+func configDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".snitch")
+}
+
+// NewManagerWithPath creates a configuration manager reading from and
+// saving to configPath instead of the default ~/.snitch/config.json, for
+// the CLI's --config flag.
+func NewManagerWithPath(configPath string) *Manager {
+	os.MkdirAll(filepath.Dir(configPath), 0755)
+
+	manager := &Manager{
+		configPath: configPath,
+		config:     defaultConfig(),
+	}
+	manager.Load()
+
+	return manager
+}
+
 // Get returns the current configuration
 func (m *Manager) Get() *Config {
 	return m.config
 }
 
+// Path returns the config file path this manager reads from and saves to.
+func (m *Manager) Path() string {
+	return m.configPath
+}
+
 // Update modifies configuration values
 func (m *Manager) Update(updates map[string]interface{}) error {
 	// This is a simplified update - in practice you'd want type-safe updates
@@ -7,15 +7,27 @@ import (
 
 // Activity represents a single tracked activity
 type Activity struct {
-	Timestamp         time.Time `json:"timestamp"`
-	Type              string    `json:"type"`              // "productive" or "distracting"
-	Activity          string    `json:"activity"`          // description
-	Application       string    `json:"application"`       // detected application
-	WindowTitle       string    `json:"window_title"`      // window title if available
-	IsProductive      bool      `json:"is_productive"`
-	Duration          int       `json:"duration"`
-	ProductivityScore float64   `json:"productivity_score"` // 0-1 score
-	Category          string    `json:"category"`          // "work", "break", "distraction"
+	Timestamp         time.Time       `json:"timestamp"`
+	Type              string          `json:"type"`         // "productive" or "distracting"
+	Activity          string          `json:"activity"`     // description
+	Application       string          `json:"application"`  // detected application
+	WindowTitle       string          `json:"window_title"` // window title if available
+	IsProductive      bool            `json:"is_productive"`
+	Duration          int             `json:"duration"`
+	ProductivityScore float64         `json:"productivity_score"`   // 0-1 score
+	Category          string          `json:"category"`             // "work", "break", "distraction"
+	Source            string          `json:"source"`               // which backend produced this activity: "groq", "ollama", "anthropic", "openai", "agentic", "fallback"
+	ToolTrace         []ToolCallTrace `json:"tool_trace,omitempty"` // tools consulted before the verdict, if any (see ml.AgenticAnalyzer)
+	Embedding         []float64       `json:"embedding,omitempty"`  // vector for Activity+WindowTitle from the Ollama backend, used by core/cluster to group recurring activity types
+}
+
+// ToolCallTrace records a single tool invocation an analyzer made while
+// reasoning about an activity, so the UI can show why a given
+// classification was produced instead of treating it as a black box.
+type ToolCallTrace struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+	Result    string `json:"result"`
 }
 
 // ActivityHistory manages the history of activities
@@ -35,9 +47,9 @@ func NewActivityHistory() *ActivityHistory {
 func (ah *ActivityHistory) Add(activity Activity) {
 	ah.mu.Lock()
 	defer ah.mu.Unlock()
-	
+
 	ah.activities = append(ah.activities, activity)
-	
+
 	// Keep only last 1000 activities
 	if len(ah.activities) > 1000 {
 		ah.activities = ah.activities[len(ah.activities)-1000:]
@@ -48,7 +60,7 @@ func (ah *ActivityHistory) Add(activity Activity) {
 func (ah *ActivityHistory) GetRecent(count int) []Activity {
 	ah.mu.RLock()
 	defer ah.mu.RUnlock()
-	
+
 	if len(ah.activities) < count {
 		return ah.activities
 	}
@@ -59,7 +71,7 @@ func (ah *ActivityHistory) GetRecent(count int) []Activity {
 func (ah *ActivityHistory) GetAll() []Activity {
 	ah.mu.RLock()
 	defer ah.mu.RUnlock()
-	
+
 	// Return a copy to prevent external modification
 	result := make([]Activity, len(ah.activities))
 	copy(result, ah.activities)
@@ -70,10 +82,115 @@ func (ah *ActivityHistory) GetAll() []Activity {
 func (ah *ActivityHistory) Count() int {
 	ah.mu.RLock()
 	defer ah.mu.RUnlock()
-	
+
 	return len(ah.activities)
 }
 
+// Filter narrows a Query or Aggregate call to activities matching specific
+// application, category, or productivity characteristics. A zero-valued
+// field means "don't filter on this".
+type Filter struct {
+	Application          string
+	Category             string
+	MinProductivityScore float64
+}
+
+// Matches reports whether activity satisfies every non-zero field in f.
+func (f Filter) Matches(activity Activity) bool {
+	if f.Application != "" && activity.Application != f.Application {
+		return false
+	}
+	if f.Category != "" && activity.Category != f.Category {
+		return false
+	}
+	if activity.ProductivityScore < f.MinProductivityScore {
+		return false
+	}
+	return true
+}
+
+// TimeBucket is one bucketed window of a QueryTimeline result: how
+// productive activities were, on average, during [Start, Start+bucket).
+type TimeBucket struct {
+	Start            time.Time
+	ProductivityRate float64
+	Count            int
+}
+
+// Timeline is the result of an Aggregate/QueryTimeline call: activity
+// bucketed over a time range, plus the total number of activities matched
+// across every bucket.
+type Timeline struct {
+	Buckets    []TimeBucket
+	TotalCount int
+}
+
+// BucketActivities buckets activities into fixed-width windows of size
+// bucket starting at start, computing each bucket's productivity rate
+// (fraction of activities with IsProductive true). Activities outside
+// [start, end) are ignored. Shared by every ActivityStore implementation so
+// SQLite/ClickHouse backends don't each reimplement the same math.
+func BucketActivities(activities []Activity, start, end time.Time, bucket time.Duration) Timeline {
+	if bucket <= 0 {
+		bucket = time.Hour
+	}
+
+	numBuckets := int(end.Sub(start)/bucket) + 1
+	if numBuckets < 1 {
+		numBuckets = 1
+	}
+
+	buckets := make([]TimeBucket, numBuckets)
+	for i := range buckets {
+		buckets[i].Start = start.Add(time.Duration(i) * bucket)
+	}
+
+	productive := make([]int, numBuckets)
+	total := 0
+	for _, activity := range activities {
+		if activity.Timestamp.Before(start) || !activity.Timestamp.Before(end) {
+			continue
+		}
+		idx := int(activity.Timestamp.Sub(start) / bucket)
+		if idx < 0 || idx >= numBuckets {
+			continue
+		}
+		buckets[idx].Count++
+		if activity.IsProductive {
+			productive[idx]++
+		}
+		total++
+	}
+
+	for i := range buckets {
+		if buckets[i].Count > 0 {
+			buckets[i].ProductivityRate = float64(productive[i]) / float64(buckets[i].Count)
+		}
+	}
+
+	return Timeline{Buckets: buckets, TotalCount: total}
+}
+
+// ActivityStore persists Activities beyond the capped in-memory window
+// ActivityHistory keeps, and answers time-range and bucketed-aggregate
+// queries over the full history. SQLiteStore (package core/store) is the
+// default backend; ClickHouseStore is available for higher-volume setups.
+type ActivityStore interface {
+	// Add persists a single activity.
+	Add(activity Activity) error
+
+	// Query returns every activity in [start, end) matching filter, ordered
+	// by timestamp ascending.
+	Query(start, end time.Time, filter Filter) ([]Activity, error)
+
+	// Aggregate buckets [start, end) into fixed-width windows of size
+	// bucket, applying filter before bucketing.
+	Aggregate(start, end time.Time, bucket time.Duration, filter Filter) (Timeline, error)
+
+	// Close releases any underlying connection/handle.
+	Close() error
+}
+
 // ProductivityStats holds productivity statistics
 type ProductivityStats struct {
 	TotalTime        time.Duration
@@ -88,29 +205,29 @@ type ProductivityStats struct {
 func (ah *ActivityHistory) CalculateStats() ProductivityStats {
 	ah.mu.RLock()
 	defer ah.mu.RUnlock()
-	
+
 	stats := ProductivityStats{
 		TopActivities: make(map[string]int),
 		TopApps:       make(map[string]int),
 	}
-	
+
 	for _, activity := range ah.activities {
 		duration := time.Duration(activity.Duration) * time.Second
 		stats.TotalTime += duration
-		
+
 		if activity.IsProductive {
 			stats.ProductiveTime += duration
 		} else {
 			stats.DistractingTime += duration
 		}
-		
+
 		stats.TopActivities[activity.Activity]++
 		stats.TopApps[activity.Application]++
 	}
-	
+
 	if stats.TotalTime > 0 {
 		stats.ProductivityRate = float64(stats.ProductiveTime) / float64(stats.TotalTime)
 	}
-	
+
 	return stats
-}
\ No newline at end of file
+}
@@ -0,0 +1,190 @@
+// Package caldav pulls a user's events for the current day from a CalDAV
+// server and exposes the one event happening right now, so the rest of
+// Snitch can ground its productivity judgments in actual scheduled context
+// instead of pure visual heuristics.
+package caldav
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+// CalendarEvent is a simplified view of a CalDAV VEVENT: just the fields
+// AnalyzeScreenshot and the TUI need.
+type CalendarEvent struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Calendar fetches today's events from a CalDAV server and caches them in
+// memory, so GetCurrentEvent/GetTaskForNow are cheap to call on every
+// screenshot analysis instead of hitting the network each time. Call
+// Refresh periodically (e.g. once per monitoring cycle) to keep the cache
+// current.
+type Calendar struct {
+	client *caldav.Client
+	events []CalendarEvent
+}
+
+// NewCalendar builds a Calendar backed by the CalDAV server at serverURL,
+// authenticating with username/password.
+func NewCalendar(serverURL, username, password string) (*Calendar, error) {
+	if serverURL == "" {
+		return nil, fmt.Errorf("caldav: server URL is required")
+	}
+
+	httpClient := webdav.HTTPClientWithBasicAuth(&http.Client{Timeout: 15 * time.Second}, username, password)
+
+	client, err := caldav.NewClient(httpClient, serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("caldav: failed to create client: %w", err)
+	}
+
+	return &Calendar{client: client}, nil
+}
+
+// Refresh re-fetches today's events from every calendar on the server and
+// replaces the cache.
+func (c *Calendar) Refresh(ctx context.Context) error {
+	calendars, err := c.client.FindCalendars(ctx, "")
+	if err != nil {
+		return fmt.Errorf("caldav: failed to list calendars: %w", err)
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := start.Add(24 * time.Hour)
+
+	var events []CalendarEvent
+	for _, cal := range calendars {
+		objects, err := c.client.QueryCalendar(ctx, cal.Path, &caldav.CalendarQuery{
+			CompFilter: caldav.CompFilter{
+				Name: "VCALENDAR",
+				Comps: []caldav.CompFilter{
+					{Name: "VEVENT", Start: start, End: end},
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("caldav: failed to query calendar %q: %w", cal.Path, err)
+		}
+
+		for _, obj := range objects {
+			events = append(events, eventsFromObject(obj)...)
+		}
+	}
+
+	c.events = events
+	return nil
+}
+
+// eventsFromObject extracts every VEVENT in a CalendarObject's iCalendar
+// data, skipping any that are missing a start/end time rather than failing
+// the whole refresh over one malformed event.
+func eventsFromObject(obj caldav.CalendarObject) []CalendarEvent {
+	if obj.Data == nil {
+		return nil
+	}
+
+	var events []CalendarEvent
+	for _, comp := range obj.Data.Children {
+		if comp.Name != "VEVENT" {
+			continue
+		}
+
+		summary := comp.Props.Get("SUMMARY")
+		dtstart := comp.Props.Get("DTSTART")
+		dtend := comp.Props.Get("DTEND")
+		if dtstart == nil || dtend == nil {
+			continue
+		}
+
+		start, err := dtstart.DateTime(time.Local)
+		if err != nil {
+			continue
+		}
+		end, err := dtend.DateTime(time.Local)
+		if err != nil {
+			continue
+		}
+
+		summaryText := ""
+		if summary != nil {
+			summaryText = summary.Value
+		}
+
+		events = append(events, CalendarEvent{Summary: summaryText, Start: start, End: end})
+	}
+
+	return events
+}
+
+// GetCurrentEvent returns the cached event covering the current moment, or
+// nil if nothing is scheduled right now. It never hits the network - call
+// Refresh first to keep the cache current.
+func (c *Calendar) GetCurrentEvent() *CalendarEvent {
+	now := time.Now()
+	for i := range c.events {
+		event := c.events[i]
+		if !now.Before(event.Start) && now.Before(event.End) {
+			return &event
+		}
+	}
+	return nil
+}
+
+// EventsWithin returns every cached event overlapping [now-window, now+window),
+// for callers (e.g. the agentic analyzer's get_calendar_events tool) that
+// want a look-ahead/look-behind window rather than only the event covering
+// this exact moment. Like GetCurrentEvent, it never hits the network - call
+// Refresh first to keep the cache current.
+func (c *Calendar) EventsWithin(window time.Duration) []CalendarEvent {
+	now := time.Now()
+	rangeStart := now.Add(-window)
+	rangeEnd := now.Add(window)
+
+	var events []CalendarEvent
+	for _, event := range c.events {
+		if event.End.After(rangeStart) && event.Start.Before(rangeEnd) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// GetTaskForNow returns the summary of the current event, or "" if nothing
+// is scheduled. The TUI uses this to auto-populate the current task from
+// the calendar when no manual task is set.
+func (c *Calendar) GetTaskForNow() string {
+	if event := c.GetCurrentEvent(); event != nil {
+		return event.Summary
+	}
+	return ""
+}
+
+// ScheduledContext renders the current event as a single line ("Scheduled:
+// 2pm-3pm 'Design review'") suitable for splicing into the vision prompt,
+// or "" if nothing is scheduled right now.
+func (c *Calendar) ScheduledContext() string {
+	event := c.GetCurrentEvent()
+	if event == nil {
+		return ""
+	}
+	return fmt.Sprintf("Scheduled: %s-%s '%s'", formatClock(event.Start), formatClock(event.End), event.Summary)
+}
+
+// formatClock renders a time as a lowercase "3pm"/"3:30pm"-style clock
+// reading.
+func formatClock(t time.Time) string {
+	if t.Minute() == 0 {
+		return strings.ToLower(t.Format("3pm"))
+	}
+	return strings.ToLower(t.Format("3:04pm"))
+}
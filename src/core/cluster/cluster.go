@@ -0,0 +1,140 @@
+// Package cluster implements online mini-batch k-means over activity
+// embeddings, so recurring activity types (e.g. "Slack channel switching")
+// can be surfaced without the user hand-labeling anything: each new
+// activity is assigned to its nearest centroid by cosine similarity, or
+// spawns a new cluster when nothing is close enough, and the winning
+// centroid is nudged toward it with an exponential moving average.
+package cluster
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// defaultThreshold is the minimum cosine similarity an activity must have
+// to its nearest centroid to join that cluster rather than spawning a new
+// one.
+const defaultThreshold = 0.85
+
+// defaultEMAAlpha weights how much a newly assigned activity nudges its
+// cluster's centroid: higher values adapt faster but are noisier.
+const defaultEMAAlpha = 0.1
+
+// Cluster is one discovered activity group: a running centroid plus enough
+// bookkeeping to summarize it for the UI without re-scanning every
+// activity ever assigned to it.
+type Cluster struct {
+	ID              int
+	Centroid        []float64
+	Count           int
+	Representative  string // description of the first activity assigned to this cluster
+	ProductivitySum float64
+}
+
+// AvgProductivity returns the cluster's running average ProductivityScore.
+func (c Cluster) AvgProductivity() float64 {
+	if c.Count == 0 {
+		return 0
+	}
+	return c.ProductivitySum / float64(c.Count)
+}
+
+// Clusterer maintains a set of clusters in memory, assigning each new
+// activity's embedding to its nearest cluster by cosine similarity, or
+// spawning a new one when nothing is close enough.
+type Clusterer struct {
+	mu        sync.RWMutex
+	clusters  []*Cluster
+	nextID    int
+	threshold float64
+	alpha     float64
+}
+
+// NewClusterer creates a Clusterer using threshold as the minimum cosine
+// similarity required to join an existing cluster. threshold <= 0 uses
+// defaultThreshold.
+func NewClusterer(threshold float64) *Clusterer {
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+	return &Clusterer{threshold: threshold, alpha: defaultEMAAlpha}
+}
+
+// Assign finds activity's nearest cluster by cosine similarity and nudges
+// its centroid toward activity's embedding with an exponential moving
+// average, spawning a new cluster if none is similar enough. Returns the
+// assigned cluster's ID, or -1 if activity has no embedding.
+func (c *Clusterer) Assign(activity core.Activity) int {
+	if len(activity.Embedding) == 0 {
+		return -1
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	best := -1
+	bestSim := -1.0
+	for i, cl := range c.clusters {
+		if sim := cosineSimilarity(cl.Centroid, activity.Embedding); sim > bestSim {
+			bestSim = sim
+			best = i
+		}
+	}
+
+	if best == -1 || bestSim < c.threshold {
+		cl := &Cluster{
+			ID:              c.nextID,
+			Centroid:        append([]float64(nil), activity.Embedding...),
+			Count:           1,
+			Representative:  activity.Activity,
+			ProductivitySum: activity.ProductivityScore,
+		}
+		c.nextID++
+		c.clusters = append(c.clusters, cl)
+		return cl.ID
+	}
+
+	cl := c.clusters[best]
+	for i := range cl.Centroid {
+		cl.Centroid[i] = (1-c.alpha)*cl.Centroid[i] + c.alpha*activity.Embedding[i]
+	}
+	cl.Count++
+	cl.ProductivitySum += activity.ProductivityScore
+	return cl.ID
+}
+
+// Clusters returns a snapshot of every discovered cluster, most-populous
+// first.
+func (c *Clusterer) Clusters() []Cluster {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]Cluster, len(c.clusters))
+	for i, cl := range c.clusters {
+		out[i] = *cl
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Count > out[j].Count })
+	return out
+}
+
+// cosineSimilarity returns the cosine similarity between a and b, or -1 if
+// either is empty, they differ in length, or either has zero magnitude.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return -1
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return -1
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
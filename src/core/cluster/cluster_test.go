@@ -0,0 +1,94 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+func TestCosineSimilarityIdenticalVectors(t *testing.T) {
+	if sim := cosineSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3}); sim < 0.999 {
+		t.Errorf("expected identical vectors to have similarity ~1, got %v", sim)
+	}
+}
+
+func TestCosineSimilarityOrthogonalVectors(t *testing.T) {
+	if sim := cosineSimilarity([]float64{1, 0}, []float64{0, 1}); sim > 1e-9 {
+		t.Errorf("expected orthogonal vectors to have similarity ~0, got %v", sim)
+	}
+}
+
+func TestCosineSimilarityMismatchedLengthOrEmpty(t *testing.T) {
+	if sim := cosineSimilarity([]float64{1, 2}, []float64{1, 2, 3}); sim != -1 {
+		t.Errorf("expected mismatched-length vectors to return -1, got %v", sim)
+	}
+	if sim := cosineSimilarity(nil, []float64{1}); sim != -1 {
+		t.Errorf("expected an empty vector to return -1, got %v", sim)
+	}
+}
+
+func TestCosineSimilarityZeroMagnitude(t *testing.T) {
+	if sim := cosineSimilarity([]float64{0, 0}, []float64{1, 2}); sim != -1 {
+		t.Errorf("expected a zero-magnitude vector to return -1, got %v", sim)
+	}
+}
+
+func TestClustererAssignSpawnsClusterForFirstActivity(t *testing.T) {
+	c := NewClusterer(0.85)
+
+	id := c.Assign(core.Activity{Activity: "reading docs", ProductivityScore: 0.8, Embedding: []float64{1, 0, 0}})
+	if id != 0 {
+		t.Fatalf("expected the first cluster to have ID 0, got %d", id)
+	}
+
+	clusters := c.Clusters()
+	if len(clusters) != 1 || clusters[0].Count != 1 {
+		t.Fatalf("expected one cluster with count 1, got %+v", clusters)
+	}
+	if clusters[0].Representative != "reading docs" {
+		t.Errorf("expected the representative to be the first activity's description, got %q", clusters[0].Representative)
+	}
+}
+
+func TestClustererAssignJoinsSimilarCluster(t *testing.T) {
+	c := NewClusterer(0.85)
+
+	c.Assign(core.Activity{Activity: "reading docs", ProductivityScore: 1.0, Embedding: []float64{1, 0, 0}})
+	id := c.Assign(core.Activity{Activity: "reading more docs", ProductivityScore: 0.0, Embedding: []float64{0.99, 0.01, 0}})
+
+	if id != 0 {
+		t.Fatalf("expected the near-identical activity to join cluster 0, got %d", id)
+	}
+
+	clusters := c.Clusters()
+	if len(clusters) != 1 {
+		t.Fatalf("expected the two similar activities to merge into one cluster, got %d", len(clusters))
+	}
+	if clusters[0].Count != 2 {
+		t.Errorf("expected cluster count 2, got %d", clusters[0].Count)
+	}
+	if got := clusters[0].AvgProductivity(); got != 0.5 {
+		t.Errorf("expected average productivity 0.5, got %v", got)
+	}
+}
+
+func TestClustererAssignSpawnsNewClusterWhenDissimilar(t *testing.T) {
+	c := NewClusterer(0.85)
+
+	c.Assign(core.Activity{Embedding: []float64{1, 0, 0}})
+	id := c.Assign(core.Activity{Embedding: []float64{0, 1, 0}})
+
+	if id != 1 {
+		t.Fatalf("expected a dissimilar activity to spawn cluster 1, got %d", id)
+	}
+	if len(c.Clusters()) != 2 {
+		t.Errorf("expected two clusters, got %d", len(c.Clusters()))
+	}
+}
+
+func TestClustererAssignNoEmbeddingReturnsNegativeOne(t *testing.T) {
+	c := NewClusterer(0.85)
+	if id := c.Assign(core.Activity{}); id != -1 {
+		t.Errorf("expected an activity with no embedding to return -1, got %d", id)
+	}
+}
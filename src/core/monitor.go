@@ -3,20 +3,22 @@ package core
 import (
 	"fmt"
 	"image"
-	"os/exec"
-	"strings"
+	"log/slog"
 
 	"github.com/kbinani/screenshot"
+
+	"github.com/varadanvk/snitch/src/logging"
 )
 
 // ScreenMonitor handles screen capture and window detection
 type ScreenMonitor struct {
 	lastCapture image.Image
+	logger      *slog.Logger
 }
 
 // NewScreenMonitor creates a new screen monitor
 func NewScreenMonitor() *ScreenMonitor {
-	return &ScreenMonitor{}
+	return &ScreenMonitor{logger: logging.With("monitor")}
 }
 
 // CaptureScreen captures the current screen
@@ -26,7 +28,8 @@ func (sm *ScreenMonitor) CaptureScreen() (image.Image, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to capture screen: %w", err)
 	}
-	
+
+	sm.logger.Debug("captured screen", "width", bounds.Dx(), "height", bounds.Dy())
 	sm.lastCapture = img
 	return img, nil
 }
@@ -42,28 +45,15 @@ type WindowInfo struct {
 	Title       string
 }
 
-// GetActiveWindow returns information about the currently active window (macOS)
+// GetActiveWindow returns information about the currently active window.
+// The actual detection mechanism is OS-specific: see window_darwin.go
+// (osascript/System Events), window_linux.go (xdotool, with a Wayland
+// fallback to swaymsg/hyprctl), and window_windows.go (user32/kernel32
+// syscalls).
 func (sm *ScreenMonitor) GetActiveWindow() (WindowInfo, error) {
-	// Get active application
-	cmd := exec.Command("osascript", "-e", 
-		`tell application "System Events" to get name of first application process whose frontmost is true`)
-	appOutput, err := cmd.Output()
-	if err != nil {
-		return WindowInfo{}, fmt.Errorf("failed to get active app: %w", err)
-	}
-	app := strings.TrimSpace(string(appOutput))
-
-	// Get window title
-	cmd = exec.Command("osascript", "-e", 
-		fmt.Sprintf(`tell application "System Events" to get title of front window of application process "%s"`, app))
-	titleOutput, err := cmd.Output()
-	title := "Unknown"
+	info, err := activeWindow()
 	if err == nil {
-		title = strings.TrimSpace(string(titleOutput))
+		sm.logger.Debug("active window", "app", info.Application, "window_title", info.Title)
 	}
-
-	return WindowInfo{
-		Application: app,
-		Title:       title,
-	}, nil
-}
\ No newline at end of file
+	return info, err
+}
@@ -0,0 +1,45 @@
+package core
+
+import "time"
+
+// Session summarizes one StartMonitoring/StopMonitoring run: when it ran,
+// what the active task was, how productive it was, and which applications
+// dominated it. SessionStore persists these so a "Session History" view has
+// something to list beyond the current run.
+type Session struct {
+	ID               string
+	Start            time.Time
+	End              time.Time
+	Task             string
+	ProductivityRate float64
+	// TopApps is up to a handful of the session's most-used applications,
+	// most-used first.
+	TopApps []string
+
+	// PromptTokens/CompletionTokens/TotalTokens are the Groq token usage
+	// accumulated over the session, a snapshot of ml.TokenStats at
+	// StopMonitoring time. Zero if Groq wasn't the active backend.
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Duration returns the session's wall-clock length, End minus Start.
+func (s Session) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// SessionStore persists Sessions across restarts, analogous to
+// ActivityStore for raw activity rows. SQLiteSessionStore (package
+// core/store) is the default and only backend.
+type SessionStore interface {
+	// Add persists a completed session.
+	Add(session Session) error
+
+	// List returns sessions ordered most-recent-first. limit <= 0 means no
+	// cap.
+	List(limit int) ([]Session, error)
+
+	// Close releases any underlying connection/handle.
+	Close() error
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// SQLiteSessionStore is the default core.SessionStore backend: a single
+// local SQLite file, mirroring SQLiteStore's approach for raw activities.
+type SQLiteSessionStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteSessionStore opens (creating if necessary) a SQLite database at
+// path and ensures its schema exists.
+func NewSQLiteSessionStore(path string) (*SQLiteSessionStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to open %q: %w", path, err)
+	}
+
+	s := &SQLiteSessionStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate creates the sessions table if it doesn't already exist.
+func (s *SQLiteSessionStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS sessions (
+			id                 TEXT NOT NULL PRIMARY KEY,
+			start              TEXT NOT NULL,
+			end                TEXT NOT NULL,
+			task               TEXT NOT NULL,
+			productivity_rate  REAL NOT NULL,
+			top_apps           TEXT NOT NULL,
+			prompt_tokens      INTEGER NOT NULL DEFAULT 0,
+			completion_tokens  INTEGER NOT NULL DEFAULT 0,
+			total_tokens       INTEGER NOT NULL DEFAULT 0
+		);
+		CREATE INDEX IF NOT EXISTS idx_sessions_start ON sessions (start);
+	`)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to run session migrations: %w", err)
+	}
+	return nil
+}
+
+// Add persists session, keyed by its ID.
+func (s *SQLiteSessionStore) Add(session core.Session) error {
+	_, err := s.db.Exec(
+		`INSERT OR REPLACE INTO sessions (id, start, end, task, productivity_rate, top_apps, prompt_tokens, completion_tokens, total_tokens)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		session.ID,
+		session.Start.UTC().Format(time.RFC3339Nano),
+		session.End.UTC().Format(time.RFC3339Nano),
+		session.Task,
+		session.ProductivityRate,
+		strings.Join(session.TopApps, ","),
+		session.PromptTokens,
+		session.CompletionTokens,
+		session.TotalTokens,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert session: %w", err)
+	}
+	return nil
+}
+
+// List returns sessions ordered most-recent-first. limit <= 0 means no cap.
+func (s *SQLiteSessionStore) List(limit int) ([]core.Session, error) {
+	query := `SELECT id, start, end, task, productivity_rate, top_apps, prompt_tokens, completion_tokens, total_tokens FROM sessions ORDER BY start DESC`
+	args := []interface{}{}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []core.Session
+	for rows.Next() {
+		var session core.Session
+		var start, end, topApps string
+		if err := rows.Scan(&session.ID, &start, &end, &session.Task, &session.ProductivityRate, &topApps, &session.PromptTokens, &session.CompletionTokens, &session.TotalTokens); err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan session: %w", err)
+		}
+		session.Start, _ = time.Parse(time.RFC3339Nano, start)
+		session.End, _ = time.Parse(time.RFC3339Nano, end)
+		if topApps != "" {
+			session.TopApps = strings.Split(topApps, ",")
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// Close closes the underlying database handle.
+func (s *SQLiteSessionStore) Close() error {
+	return s.db.Close()
+}
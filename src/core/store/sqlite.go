@@ -0,0 +1,226 @@
+// Package store provides core.ActivityStore implementations: SQLiteStore,
+// the default local-file backend, and ClickHouseStore for higher-volume
+// deployments that want a real time-series database.
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// SQLiteStore is the default core.ActivityStore backend: a single local
+// SQLite file, which is enough durability for a single user's history
+// without requiring any external service. Raw rows older than
+// retentionDays are pruned on every Add; retentionDays <= 0 disables
+// pruning and keeps raw rows forever.
+type SQLiteStore struct {
+	db            *sql.DB
+	retentionDays int
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at path,
+// ensures its schema exists, and prunes raw activity rows older than
+// retentionDays on every Add (0 or negative keeps everything).
+func NewSQLiteStore(path string, retentionDays int) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to open %q: %w", path, err)
+	}
+
+	s := &SQLiteStore{db: db, retentionDays: retentionDays}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate creates the activities table and its timestamp index if they
+// don't already exist.
+func (s *SQLiteStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS activities (
+			timestamp           TEXT NOT NULL,
+			type                TEXT NOT NULL,
+			activity            TEXT NOT NULL,
+			application         TEXT NOT NULL,
+			window_title        TEXT NOT NULL,
+			is_productive       INTEGER NOT NULL,
+			duration            INTEGER NOT NULL,
+			productivity_score  REAL NOT NULL,
+			category            TEXT NOT NULL,
+			source              TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_activities_timestamp ON activities (timestamp);
+
+		CREATE TABLE IF NOT EXISTS activity_aggregates (
+			bucket_start     TEXT NOT NULL PRIMARY KEY,
+			count            INTEGER NOT NULL,
+			productive_count INTEGER NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to run migrations: %w", err)
+	}
+	return nil
+}
+
+// Add persists activity and rolls it into the hourly activity_aggregates
+// table, which is never pruned, so historical productivity rates remain
+// queryable after raw rows age out under the retention policy.
+func (s *SQLiteStore) Add(activity core.Activity) error {
+	ts := activity.Timestamp.UTC()
+
+	_, err := s.db.Exec(
+		`INSERT INTO activities (timestamp, type, activity, application, window_title, is_productive, duration, productivity_score, category, source)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ts.Format(time.RFC3339Nano),
+		activity.Type, activity.Activity, activity.Application, activity.WindowTitle,
+		activity.IsProductive, activity.Duration, activity.ProductivityScore, activity.Category, activity.Source,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert activity: %w", err)
+	}
+
+	bucketStart := ts.Truncate(time.Hour).Format(time.RFC3339)
+	productive := 0
+	if activity.IsProductive {
+		productive = 1
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO activity_aggregates (bucket_start, count, productive_count)
+		VALUES (?, 1, ?)
+		ON CONFLICT(bucket_start) DO UPDATE SET
+			count = count + 1,
+			productive_count = productive_count + excluded.productive_count
+	`, bucketStart, productive)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to update aggregate: %w", err)
+	}
+
+	return s.pruneRaw()
+}
+
+// pruneRaw deletes raw activity rows older than retentionDays. No-op when
+// retentionDays <= 0.
+func (s *SQLiteStore) pruneRaw() error {
+	if s.retentionDays <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -s.retentionDays).Format(time.RFC3339Nano)
+	if _, err := s.db.Exec(`DELETE FROM activities WHERE timestamp < ?`, cutoff); err != nil {
+		return fmt.Errorf("sqlite: failed to prune raw activities: %w", err)
+	}
+	return nil
+}
+
+// Query returns every activity in [start, end) matching filter, ordered by
+// timestamp ascending. Only covers activities still within the raw
+// retention window.
+func (s *SQLiteStore) Query(start, end time.Time, filter core.Filter) ([]core.Activity, error) {
+	rows, err := s.db.Query(
+		`SELECT timestamp, type, activity, application, window_title, is_productive, duration, productivity_score, category, source
+		 FROM activities WHERE timestamp >= ? AND timestamp < ? ORDER BY timestamp ASC`,
+		start.UTC().Format(time.RFC3339Nano), end.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query activities: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []core.Activity
+	for rows.Next() {
+		var activity core.Activity
+		var ts string
+		if err := rows.Scan(&ts, &activity.Type, &activity.Activity, &activity.Application, &activity.WindowTitle,
+			&activity.IsProductive, &activity.Duration, &activity.ProductivityScore, &activity.Category, &activity.Source); err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan activity: %w", err)
+		}
+		activity.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+		if filter.Matches(activity) {
+			activities = append(activities, activity)
+		}
+	}
+	return activities, rows.Err()
+}
+
+// Aggregate buckets [start, end) into fixed-width windows of size bucket.
+// An unfiltered request falls back to the hourly activity_aggregates table
+// so it keeps working once raw rows for that range have been pruned;
+// a filtered request always reads raw rows, and so is limited to whatever
+// is still within the retention window.
+func (s *SQLiteStore) Aggregate(start, end time.Time, bucket time.Duration, filter core.Filter) (core.Timeline, error) {
+	if filter == (core.Filter{}) {
+		return s.aggregateFromRollup(start, end, bucket)
+	}
+
+	activities, err := s.Query(start, end, filter)
+	if err != nil {
+		return core.Timeline{}, err
+	}
+	return core.BucketActivities(activities, start, end, bucket), nil
+}
+
+// aggregateFromRollup answers Aggregate using the never-pruned
+// activity_aggregates table, re-bucketing its hourly rows into bucket-sized
+// windows.
+func (s *SQLiteStore) aggregateFromRollup(start, end time.Time, bucket time.Duration) (core.Timeline, error) {
+	rows, err := s.db.Query(
+		`SELECT bucket_start, count, productive_count FROM activity_aggregates
+		 WHERE bucket_start >= ? AND bucket_start < ? ORDER BY bucket_start ASC`,
+		start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return core.Timeline{}, fmt.Errorf("sqlite: failed to query aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	if bucket <= 0 {
+		bucket = time.Hour
+	}
+	numBuckets := int(end.Sub(start)/bucket) + 1
+	buckets := make([]core.TimeBucket, numBuckets)
+	productive := make([]int, numBuckets)
+	for i := range buckets {
+		buckets[i].Start = start.Add(time.Duration(i) * bucket)
+	}
+
+	total := 0
+	for rows.Next() {
+		var bucketStart string
+		var count, productiveCount int
+		if err := rows.Scan(&bucketStart, &count, &productiveCount); err != nil {
+			return core.Timeline{}, fmt.Errorf("sqlite: failed to scan aggregate: %w", err)
+		}
+		ts, err := time.Parse(time.RFC3339, bucketStart)
+		if err != nil {
+			continue
+		}
+		idx := int(ts.Sub(start) / bucket)
+		if idx < 0 || idx >= numBuckets {
+			continue
+		}
+		buckets[idx].Count += count
+		productive[idx] += productiveCount
+		total += count
+	}
+
+	for i := range buckets {
+		if buckets[i].Count > 0 {
+			buckets[i].ProductivityRate = float64(productive[i]) / float64(buckets[i].Count)
+		}
+	}
+
+	return core.Timeline{Buckets: buckets, TotalCount: total}, rows.Err()
+}
+
+// Close closes the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+func newTestStore(t *testing.T, retentionDays int) *SQLiteStore {
+	t.Helper()
+
+	s, err := NewSQLiteStore(filepath.Join(t.TempDir(), "snitch.db"), retentionDays)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSQLiteStoreAddAndQuery(t *testing.T) {
+	s := newTestStore(t, 0)
+	start := time.Date(2026, 7, 29, 9, 0, 0, 0, time.UTC)
+
+	if err := s.Add(core.Activity{Timestamp: start, Application: "Slack", IsProductive: true}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.Add(core.Activity{Timestamp: start.Add(time.Hour), Application: "Chrome", IsProductive: false}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	activities, err := s.Query(start, start.Add(2*time.Hour), core.Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(activities) != 2 {
+		t.Fatalf("expected 2 activities, got %d", len(activities))
+	}
+	if activities[0].Application != "Slack" || activities[1].Application != "Chrome" {
+		t.Errorf("expected activities ordered by timestamp ascending, got %+v", activities)
+	}
+}
+
+func TestSQLiteStoreQueryAppliesFilter(t *testing.T) {
+	s := newTestStore(t, 0)
+	start := time.Date(2026, 7, 29, 9, 0, 0, 0, time.UTC)
+
+	if err := s.Add(core.Activity{Timestamp: start, Application: "Slack", IsProductive: true}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.Add(core.Activity{Timestamp: start, Application: "Chrome", IsProductive: false}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	activities, err := s.Query(start, start.Add(time.Hour), core.Filter{Application: "Slack"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(activities) != 1 || activities[0].Application != "Slack" {
+		t.Fatalf("expected the filter to keep only Slack, got %+v", activities)
+	}
+}
+
+func TestSQLiteStoreAggregateFromRollup(t *testing.T) {
+	s := newTestStore(t, 0)
+	start := time.Date(2026, 7, 29, 9, 0, 0, 0, time.UTC)
+
+	if err := s.Add(core.Activity{Timestamp: start, IsProductive: true}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.Add(core.Activity{Timestamp: start.Add(10 * time.Minute), IsProductive: false}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.Add(core.Activity{Timestamp: start.Add(time.Hour), IsProductive: true}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	timeline, err := s.Aggregate(start, start.Add(2*time.Hour), time.Hour, core.Filter{})
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if timeline.TotalCount != 3 {
+		t.Fatalf("expected 3 total activities, got %d", timeline.TotalCount)
+	}
+	if len(timeline.Buckets) != 3 {
+		t.Fatalf("expected 3 hourly buckets, got %d", len(timeline.Buckets))
+	}
+	if timeline.Buckets[0].Count != 2 {
+		t.Errorf("expected the first bucket to have 2 activities, got %d", timeline.Buckets[0].Count)
+	}
+	if got := timeline.Buckets[0].ProductivityRate; got != 0.5 {
+		t.Errorf("expected the first bucket's productivity rate to be 0.5, got %v", got)
+	}
+	if timeline.Buckets[1].Count != 1 || timeline.Buckets[1].ProductivityRate != 1 {
+		t.Errorf("expected the second bucket to have 1 fully-productive activity, got %+v", timeline.Buckets[1])
+	}
+}
+
+func TestSQLiteStoreAggregateWithFilterFallsBackToRawRows(t *testing.T) {
+	s := newTestStore(t, 0)
+	start := time.Date(2026, 7, 29, 9, 0, 0, 0, time.UTC)
+
+	if err := s.Add(core.Activity{Timestamp: start, Application: "Slack", IsProductive: true}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := s.Add(core.Activity{Timestamp: start, Application: "Chrome", IsProductive: false}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	timeline, err := s.Aggregate(start, start.Add(time.Hour), time.Hour, core.Filter{Application: "Slack"})
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if timeline.TotalCount != 1 {
+		t.Fatalf("expected the filter to keep only the Slack activity, got total %d", timeline.TotalCount)
+	}
+}
+
+func TestSQLiteStorePruneRawRemovesOldRows(t *testing.T) {
+	s := newTestStore(t, 1)
+
+	old := time.Now().UTC().AddDate(0, 0, -5)
+	if err := s.Add(core.Activity{Timestamp: old}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	recent := time.Now().UTC()
+	if err := s.Add(core.Activity{Timestamp: recent}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	activities, err := s.Query(old.AddDate(0, 0, -1), recent.Add(time.Hour), core.Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(activities) != 1 {
+		t.Fatalf("expected only the recent row to survive retention pruning, got %d rows", len(activities))
+	}
+}
@@ -0,0 +1,34 @@
+//go:build darwin
+
+package core
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// activeWindow shells out to System Events via osascript to find the
+// frontmost application and the title of its front window.
+func activeWindow() (WindowInfo, error) {
+	cmd := exec.Command("osascript", "-e",
+		`tell application "System Events" to get name of first application process whose frontmost is true`)
+	appOutput, err := cmd.Output()
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("failed to get active app: %w", err)
+	}
+	app := strings.TrimSpace(string(appOutput))
+
+	cmd = exec.Command("osascript", "-e",
+		fmt.Sprintf(`tell application "System Events" to get title of front window of application process "%s"`, app))
+	titleOutput, err := cmd.Output()
+	title := "Unknown"
+	if err == nil {
+		title = strings.TrimSpace(string(titleOutput))
+	}
+
+	return WindowInfo{
+		Application: app,
+		Title:       title,
+	}, nil
+}
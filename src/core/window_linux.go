@@ -0,0 +1,125 @@
+//go:build linux
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// activeWindow detects the active window under X11 via xdotool, falling
+// back to swaymsg (sway) or hyprctl (Hyprland) when xdotool can't see a
+// window because the session is pure Wayland.
+func activeWindow() (WindowInfo, error) {
+	if info, err := activeWindowX11(); err == nil {
+		return info, nil
+	}
+	if info, err := activeWindowSway(); err == nil {
+		return info, nil
+	}
+	if info, err := activeWindowHyprland(); err == nil {
+		return info, nil
+	}
+	return WindowInfo{}, fmt.Errorf("no active window provider available (tried xdotool, swaymsg, hyprctl)")
+}
+
+// activeWindowX11 reads the active window's id via xdotool and then its
+// name and WM class.
+func activeWindowX11() (WindowInfo, error) {
+	idOutput, err := exec.Command("xdotool", "getactivewindow").Output()
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("xdotool getactivewindow: %w", err)
+	}
+	windowID := strings.TrimSpace(string(idOutput))
+
+	titleOutput, err := exec.Command("xdotool", "getwindowname", windowID).Output()
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("xdotool getwindowname: %w", err)
+	}
+	title := strings.TrimSpace(string(titleOutput))
+
+	app := title
+	if classOutput, err := exec.Command("xdotool", "getwindowclassname", windowID).Output(); err == nil {
+		app = strings.TrimSpace(string(classOutput))
+	}
+
+	return WindowInfo{Application: app, Title: title}, nil
+}
+
+// swayNode is the subset of `swaymsg -t get_tree`'s JSON tree needed to
+// find the focused window.
+type swayNode struct {
+	Name             string     `json:"name"`
+	AppID            string     `json:"app_id"`
+	Focused          bool       `json:"focused"`
+	Nodes            []swayNode `json:"nodes"`
+	FloatingNodes    []swayNode `json:"floating_nodes"`
+	WindowProperties struct {
+		Class string `json:"class"`
+	} `json:"window_properties"`
+}
+
+// activeWindowSway queries sway's IPC tree for the focused node, used as a
+// Wayland fallback.
+func activeWindowSway() (WindowInfo, error) {
+	out, err := exec.Command("swaymsg", "-t", "get_tree").Output()
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("swaymsg get_tree: %w", err)
+	}
+
+	var root swayNode
+	if err := json.Unmarshal(out, &root); err != nil {
+		return WindowInfo{}, fmt.Errorf("failed to parse sway tree: %w", err)
+	}
+
+	node, ok := findFocusedSwayNode(root)
+	if !ok {
+		return WindowInfo{}, fmt.Errorf("no focused sway node found")
+	}
+
+	app := node.AppID
+	if app == "" {
+		app = node.WindowProperties.Class
+	}
+	return WindowInfo{Application: app, Title: node.Name}, nil
+}
+
+// findFocusedSwayNode walks a sway tree depth-first looking for the node
+// with "focused": true.
+func findFocusedSwayNode(n swayNode) (swayNode, bool) {
+	if n.Focused {
+		return n, true
+	}
+	for _, child := range n.Nodes {
+		if found, ok := findFocusedSwayNode(child); ok {
+			return found, true
+		}
+	}
+	for _, child := range n.FloatingNodes {
+		if found, ok := findFocusedSwayNode(child); ok {
+			return found, true
+		}
+	}
+	return swayNode{}, false
+}
+
+// activeWindowHyprland queries Hyprland's IPC for the active window,
+// used as a Wayland fallback on Hyprland compositors.
+func activeWindowHyprland() (WindowInfo, error) {
+	out, err := exec.Command("hyprctl", "activewindow", "-j").Output()
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("hyprctl activewindow: %w", err)
+	}
+
+	var result struct {
+		Class string `json:"class"`
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return WindowInfo{}, fmt.Errorf("failed to parse hyprctl output: %w", err)
+	}
+
+	return WindowInfo{Application: result.Class, Title: result.Title}, nil
+}
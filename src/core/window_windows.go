@@ -0,0 +1,56 @@
+//go:build windows
+
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const processQueryLimitedInformation = 0x1000
+
+var (
+	user32   = syscall.NewLazyDLL("user32.dll")
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procGetForegroundWindow        = user32.NewProc("GetForegroundWindow")
+	procGetWindowTextW             = user32.NewProc("GetWindowTextW")
+	procGetWindowThreadProcessId   = user32.NewProc("GetWindowThreadProcessId")
+	procOpenProcess                = kernel32.NewProc("OpenProcess")
+	procQueryFullProcessImageNameW = kernel32.NewProc("QueryFullProcessImageNameW")
+	procCloseHandle                = kernel32.NewProc("CloseHandle")
+)
+
+// activeWindow uses user32/kernel32 syscalls to find the foreground
+// window's title and the executable name of the process that owns it.
+func activeWindow() (WindowInfo, error) {
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 {
+		return WindowInfo{}, fmt.Errorf("no foreground window")
+	}
+
+	titleBuf := make([]uint16, 256)
+	procGetWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&titleBuf[0])), uintptr(len(titleBuf)))
+	title := syscall.UTF16ToString(titleBuf)
+
+	var pid uint32
+	procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+
+	app := "Unknown"
+	if handle, _, _ := procOpenProcess.Call(processQueryLimitedInformation, 0, uintptr(pid)); handle != 0 {
+		defer procCloseHandle.Call(handle)
+
+		pathBuf := make([]uint16, 260)
+		size := uint32(len(pathBuf))
+		ret, _, _ := procQueryFullProcessImageNameW.Call(
+			handle, 0, uintptr(unsafe.Pointer(&pathBuf[0])), uintptr(unsafe.Pointer(&size)),
+		)
+		if ret != 0 {
+			app = filepath.Base(syscall.UTF16ToString(pathBuf[:size]))
+		}
+	}
+
+	return WindowInfo{Application: app, Title: title}, nil
+}
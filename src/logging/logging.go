@@ -0,0 +1,64 @@
+// Package logging provides Snitch's structured logger: a package-level
+// slog.Logger that every component logs through with contextual fields
+// ("component", "backend", "app", "session_id", ...) instead of ad-hoc
+// log.Printf strings, plus an always-on ring-buffer sink the TUI can tail
+// for an in-app debug pane.
+package logging
+
+import (
+	"log/slog"
+	"os"
+)
+
+// Logger is the package-level structured logger every component logs
+// through. It defaults to a text handler on stderr at info level, with
+// the ring buffer always attached, so logging works before Init runs
+// (e.g. in tests or tools that import a component package directly).
+var Logger = slog.New(newFanOutHandler(slog.NewTextHandler(os.Stderr, nil)))
+
+// Ring is the in-memory ring-buffer sink backing the TUI's debug pane.
+// It's wired into Logger by both the default setup above and Init, so it
+// always reflects everything the process has logged regardless of the
+// configured --log-format/--log-level.
+var Ring = NewRingBuffer(200)
+
+// Init reconfigures Logger for the --log-format/--log-level flags.
+// format is "text" (default) or "json"; level is "debug", "info"
+// (default), "warn", or "error".
+func Init(format, level string) {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	var out slog.Handler
+	if format == "json" {
+		out = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		out = slog.NewTextHandler(os.Stderr, opts)
+	}
+
+	Logger = slog.New(newFanOutHandler(out))
+}
+
+// With returns Logger scoped to component, the "component" field every
+// log call made through the result carries, e.g. logging.With("monitor").
+func With(component string) *slog.Logger {
+	return Logger.With("component", component)
+}
+
+// newFanOutHandler builds the handler chain Logger/Init use: out (the
+// user-visible text/JSON sink) plus the package's shared Ring buffer.
+func newFanOutHandler(out slog.Handler) slog.Handler {
+	return &multiHandler{handlers: []slog.Handler{out, Ring}}
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
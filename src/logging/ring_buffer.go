@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Record is one captured log line, kept by RingBuffer for the TUI's debug
+// pane.
+type Record struct {
+	Time    time.Time
+	Level   string
+	Message string
+	Fields  map[string]string
+}
+
+// ringStore is the buffer RingBuffer writes into, shared across every
+// handler produced by WithAttrs so a chain like Logger.With("component",
+// "monitor") still lands in the one buffer the TUI tails.
+type ringStore struct {
+	mu      sync.Mutex
+	records []Record
+	cap     int
+}
+
+// RingBuffer is a fixed-capacity slog.Handler that appends every record it
+// handles (merged with any attrs from a WithAttrs chain) into a shared,
+// capped in-memory buffer, dropping the oldest record once full, so the
+// TUI's debug pane can show recent events without tailing a log file.
+type RingBuffer struct {
+	store *ringStore
+	attrs []slog.Attr
+}
+
+// NewRingBuffer creates a RingBuffer keeping at most capacity records.
+func NewRingBuffer(capacity int) *RingBuffer {
+	return &RingBuffer{store: &ringStore{cap: capacity}}
+}
+
+// Enabled always returns true: the debug pane should see every record
+// regardless of the --log-level configured for the visible text/JSON sink.
+func (r *RingBuffer) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (r *RingBuffer) Handle(_ context.Context, rec slog.Record) error {
+	fields := make(map[string]string, rec.NumAttrs()+len(r.attrs))
+	for _, a := range r.attrs {
+		fields[a.Key] = a.Value.String()
+	}
+	rec.Attrs(func(a slog.Attr) bool {
+		fields[a.Key] = a.Value.String()
+		return true
+	})
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.records = append(r.store.records, Record{
+		Time:    rec.Time,
+		Level:   rec.Level.String(),
+		Message: rec.Message,
+		Fields:  fields,
+	})
+	if len(r.store.records) > r.store.cap {
+		r.store.records = r.store.records[len(r.store.records)-r.store.cap:]
+	}
+	return nil
+}
+
+func (r *RingBuffer) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &RingBuffer{store: r.store, attrs: append(append([]slog.Attr{}, r.attrs...), attrs...)}
+}
+
+func (r *RingBuffer) WithGroup(string) slog.Handler {
+	// Groups don't matter for the flattened key/value view the debug pane
+	// shows, so just keep logging into the same buffer.
+	return r
+}
+
+// Records returns a snapshot of the most recent log records, oldest first.
+func (r *RingBuffer) Records() []Record {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	out := make([]Record, len(r.store.records))
+	copy(out, r.store.records)
+	return out
+}
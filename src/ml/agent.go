@@ -0,0 +1,197 @@
+package ml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// Tool describes a Go function the model may invoke via OpenAI-style
+// tool_calls, following the `{"type": "function", "function": {...}}` shape.
+type Tool struct {
+	Type     string       `json:"type"`
+	Function FunctionSpec `json:"function"`
+}
+
+// FunctionSpec is the JSON-schema description of a callable tool.
+type FunctionSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ToolCall is a single invocation the model requested.
+type ToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// ToolFunc is the Go implementation backing a Tool. It receives the raw
+// JSON arguments the model supplied and returns the string result that gets
+// appended back to the conversation as a role:"tool" message.
+type ToolFunc func(ctx context.Context, args json.RawMessage) (string, error)
+
+// Toolbox maps tool names to their Go implementations.
+type Toolbox map[string]ToolFunc
+
+// Agent bundles a system prompt, an expected response schema, and the
+// tools it's allowed to call. GroqAnalyzer.AnalyzeScreenshot and RunAgent
+// use it to configure each request instead of hard-coding a single prompt.
+type Agent struct {
+	Name           string
+	SystemPrompt   string
+	ResponseFormat *ResponseFormat
+	Tools          []Tool
+	Toolbox        Toolbox
+}
+
+// AgentContext carries the runtime dependencies agents need to build
+// toolbox closures (activity history, current task) without the ml
+// package importing the rest of the application.
+type AgentContext struct {
+	History *core.ActivityHistory
+	GetTask func() string
+}
+
+// NewClassifierAgent reproduces the analyzer's original fixed behavior:
+// classify a screenshot into the activity/productivity JSON schema, with no
+// tool access.
+func NewClassifierAgent() *Agent {
+	return &Agent{
+		Name: "classifier",
+		SystemPrompt: "You are Snitch's activity classifier. Given a screenshot and window " +
+			"info, identify what the user is doing and how productive it is. Respond with " +
+			"ONLY the requested JSON object, no other text.",
+		ResponseFormat: &ResponseFormat{Type: "json_object"},
+	}
+}
+
+// NewCoachAgent builds an agent that produces a short motivational nudge
+// referencing the user's current task instead of a classification.
+func NewCoachAgent() *Agent {
+	return &Agent{
+		Name: "coach",
+		SystemPrompt: "You are Snitch's productivity coach. Given a screenshot, window info, " +
+			"and the user's stated task, write one short, encouraging sentence that nudges " +
+			"them back toward that task if they've drifted, or affirms good focus if they " +
+			"haven't. Be specific about what you observe. Respond with plain text, no JSON.",
+	}
+}
+
+// NewAuditorAgent builds an agent that can call tools to inspect recent
+// history and write a daily summary, rather than analyzing a single
+// screenshot.
+func NewAuditorAgent(agentCtx AgentContext) *Agent {
+	tools := []Tool{
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "list_recent_activities",
+				Description: "List the most recent tracked activities.",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"window": {"type": "integer", "description": "How many recent activities to return"}
+					},
+					"required": ["window"]
+				}`),
+			},
+		},
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "get_task",
+				Description: "Get the user's current stated task.",
+				Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+		},
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "get_productivity_stats",
+				Description: "Get aggregate productivity statistics for the tracked session.",
+				Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+		},
+	}
+
+	toolbox := Toolbox{
+		"list_recent_activities": func(ctx context.Context, args json.RawMessage) (string, error) {
+			var params struct {
+				Window int `json:"window"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil || params.Window <= 0 {
+				params.Window = 20
+			}
+			if agentCtx.History == nil {
+				return "[]", nil
+			}
+			recent := agentCtx.History.GetRecent(params.Window)
+			out, err := json.Marshal(recent)
+			if err != nil {
+				return "", err
+			}
+			return string(out), nil
+		},
+		"get_task": func(ctx context.Context, args json.RawMessage) (string, error) {
+			if agentCtx.GetTask == nil {
+				return "", nil
+			}
+			return agentCtx.GetTask(), nil
+		},
+		"get_productivity_stats": func(ctx context.Context, args json.RawMessage) (string, error) {
+			if agentCtx.History == nil {
+				return "{}", nil
+			}
+			stats := agentCtx.History.CalculateStats()
+			out, err := json.Marshal(stats)
+			if err != nil {
+				return "", err
+			}
+			return string(out), nil
+		},
+	}
+
+	return &Agent{
+		Name: "auditor",
+		SystemPrompt: "You are Snitch's auditor. Use the available tools to inspect recent " +
+			"activity and the user's stated task, then write a concise daily summary covering " +
+			"overall productivity, notable patterns, and one suggestion for tomorrow.",
+		Tools:   tools,
+		Toolbox: toolbox,
+	}
+}
+
+// BuiltinAgents returns the three shipped agents (classifier, coach,
+// auditor), wiring the auditor's toolbox to agentCtx.
+func BuiltinAgents(agentCtx AgentContext) map[string]*Agent {
+	return map[string]*Agent{
+		"classifier": NewClassifierAgent(),
+		"coach":      NewCoachAgent(),
+		"auditor":    NewAuditorAgent(agentCtx),
+	}
+}
+
+// callTool looks up and invokes the toolbox function matching a ToolCall,
+// returning a human-readable error string instead of failing the loop when
+// the tool name is unknown or arguments don't parse - mirroring how the
+// OpenAI tool-call convention expects failures to be reported back to the
+// model rather than aborting the conversation.
+func (a *Agent) callTool(ctx context.Context, call ToolCall) string {
+	fn, ok := a.Toolbox[call.Function.Name]
+	if !ok {
+		return fmt.Sprintf("error: unknown tool %q", call.Function.Name)
+	}
+
+	result, err := fn(ctx, json.RawMessage(call.Function.Arguments))
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return result
+}
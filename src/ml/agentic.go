@@ -0,0 +1,278 @@
+package ml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/caldav"
+)
+
+// toolTraceKey is the context key AgenticAnalyzer uses to thread a trace
+// recorder through Agent.callTool without widening that shared signature
+// just for one agent's bookkeeping.
+type toolTraceKey struct{}
+
+// withToolTrace returns a context that the agentic toolbox's functions use
+// to append a core.ToolCallTrace entry for each tool they execute.
+func withToolTrace(ctx context.Context, trace *[]core.ToolCallTrace) context.Context {
+	return context.WithValue(ctx, toolTraceKey{}, trace)
+}
+
+// recordToolCall appends a trace entry if ctx was built with withToolTrace,
+// and is a no-op otherwise (e.g. when the agentic agent's toolbox is
+// invoked without a trace recorder attached).
+func recordToolCall(ctx context.Context, name, args, result string) {
+	trace, ok := ctx.Value(toolTraceKey{}).(*[]core.ToolCallTrace)
+	if !ok || trace == nil {
+		return
+	}
+	*trace = append(*trace, core.ToolCallTrace{Name: name, Arguments: args, Result: result})
+}
+
+// AgenticAnalyzer wraps a GroqAnalyzer configured with tools that reach
+// into the user's environment - browser tabs, git history, calendar, their
+// stated task - so the model can gather context beyond the screenshot
+// before producing its final activity verdict. It reuses GroqAnalyzer's
+// request/response plumbing and tool-call loop rather than re-implementing
+// them, since Groq, Anthropic and OpenAI all speak the same
+// `{"type": "function", "function": {...}}` tool_calls convention the
+// Agent type already models.
+type AgenticAnalyzer struct {
+	groq  *GroqAnalyzer
+	agent *Agent
+}
+
+// NewAgenticAnalyzer builds an AgenticAnalyzer backed by Groq, wiring its
+// toolbox to agentCtx the same way NewAuditorAgent does.
+func NewAgenticAnalyzer(apiKey string, agentCtx AgentContext) *AgenticAnalyzer {
+	groq := NewGroqAnalyzer(apiKey)
+	agent := newAgenticAgent(agentCtx, groq.Calendar)
+	groq.SetAgent(agent)
+
+	return &AgenticAnalyzer{
+		groq:  groq,
+		agent: agent,
+	}
+}
+
+// Name identifies this backend for provenance/logging.
+func (aa *AgenticAnalyzer) Name() string { return "agentic" }
+
+// IsAvailable reports whether the underlying Groq analyzer has an API key.
+func (aa *AgenticAnalyzer) IsAvailable() bool { return aa.groq.IsAvailable() }
+
+// SetCalendar wires a caldav.Calendar in so subsequent prompts include the
+// user's current scheduled event as context.
+func (aa *AgenticAnalyzer) SetCalendar(cal *caldav.Calendar) {
+	aa.groq.SetCalendar(cal)
+}
+
+// AnalyzeScreenshot sends the screenshot plus the agentic agent's tools to
+// Groq, lets the model call as many tools as it needs via the shared
+// converse loop, and returns the resulting Activity with ToolTrace
+// populated from whatever tools it actually invoked.
+func (aa *AgenticAnalyzer) AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	imageB64, err := aa.groq.imageToBase64(img)
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	prompt := buildVisionPrompt(windowInfo, currentTask, aa.groq.scheduledContext())
+
+	messages := []Message{}
+	if aa.agent.SystemPrompt != "" {
+		messages = append(messages, textMessage("system", aa.agent.SystemPrompt))
+	}
+	messages = append(messages, Message{
+		Role: "user",
+		Content: []Content{
+			{Type: "text", Text: prompt},
+			{Type: "image_url", ImageURL: &ImageURL{URL: "data:image/png;base64," + imageB64}},
+		},
+	})
+
+	var trace []core.ToolCallTrace
+	ctx := withToolTrace(context.Background(), &trace)
+
+	response, err := aa.groq.converse(ctx, messages)
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to query agentic analyzer: %w", err)
+	}
+
+	activity, err := parseVisionResponse(response, windowInfo, monitoringInterval, "agentic")
+	if err != nil {
+		return core.Activity{}, err
+	}
+	activity.ToolTrace = trace
+
+	return activity, nil
+}
+
+// newAgenticAgent builds the agent the model uses while classifying: the
+// same JSON verdict schema as the plain classifier, but with tools to
+// inspect browser tabs, recent git commits, calendar events and the user's
+// stated task first. calendar is called lazily on each get_calendar_events
+// invocation (rather than captured once) since SetCalendar may wire a
+// caldav.Calendar in after this agent is built.
+func newAgenticAgent(agentCtx AgentContext, calendar func() *caldav.Calendar) *Agent {
+	tools := []Tool{
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "get_active_browser_tabs",
+				Description: "List the tab titles open in the frontmost browser window.",
+				Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+		},
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "get_recent_git_commits",
+				Description: "List the most recent commits in a local git repository.",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"repo_path": {"type": "string", "description": "Filesystem path to a git repository"}
+					},
+					"required": ["repo_path"]
+				}`),
+			},
+		},
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "get_calendar_events",
+				Description: "List calendar events within a time window around now.",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"window": {"type": "integer", "description": "Minutes before and after now to search"}
+					},
+					"required": ["window"]
+				}`),
+			},
+		},
+		{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        "read_current_task",
+				Description: "Read the user's stated current task.",
+				Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+		},
+	}
+
+	toolbox := Toolbox{
+		"get_active_browser_tabs": func(ctx context.Context, args json.RawMessage) (string, error) {
+			result, err := activeBrowserTabs()
+			if err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			}
+			recordToolCall(ctx, "get_active_browser_tabs", string(args), result)
+			return result, nil
+		},
+		"get_recent_git_commits": func(ctx context.Context, args json.RawMessage) (string, error) {
+			var params struct {
+				RepoPath string `json:"repo_path"`
+			}
+			result := ""
+			if err := json.Unmarshal(args, &params); err != nil || params.RepoPath == "" {
+				result = "error: repo_path is required"
+			} else if commits, err := recentGitCommits(params.RepoPath); err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			} else {
+				result = commits
+			}
+			recordToolCall(ctx, "get_recent_git_commits", string(args), result)
+			return result, nil
+		},
+		"get_calendar_events": func(ctx context.Context, args json.RawMessage) (string, error) {
+			var params struct {
+				Window int `json:"window"`
+			}
+			if err := json.Unmarshal(args, &params); err != nil || params.Window <= 0 {
+				params.Window = 60
+			}
+
+			cal := calendar()
+			result := ""
+			if cal == nil {
+				result = "calendar integration not configured"
+			} else {
+				result = formatCalendarEvents(cal.EventsWithin(time.Duration(params.Window) * time.Minute))
+			}
+			recordToolCall(ctx, "get_calendar_events", string(args), result)
+			return result, nil
+		},
+		"read_current_task": func(ctx context.Context, args json.RawMessage) (string, error) {
+			result := ""
+			if agentCtx.GetTask != nil {
+				result = agentCtx.GetTask()
+			}
+			recordToolCall(ctx, "read_current_task", string(args), result)
+			return result, nil
+		},
+	}
+
+	return &Agent{
+		Name: "agentic",
+		SystemPrompt: "You are Snitch's agentic activity classifier. Before answering, call " +
+			"whichever tools would help you understand what the user is actually doing - their " +
+			"open browser tabs, recent git commits, calendar, or stated task - then classify the " +
+			"screenshot using the same JSON schema the plain classifier uses. Respond with ONLY " +
+			"the requested JSON object once you're done, no other text.",
+		ResponseFormat: &ResponseFormat{Type: "json_object"},
+		Tools:          tools,
+		Toolbox:        toolbox,
+	}
+}
+
+// formatCalendarEvents renders a list of calendar events as one "HH:MM-HH:MM
+// Summary" line per event, or a plain notice if the window is empty.
+func formatCalendarEvents(events []caldav.CalendarEvent) string {
+	if len(events) == 0 {
+		return "no events in that window"
+	}
+
+	lines := make([]string, len(events))
+	for i, event := range events {
+		lines[i] = fmt.Sprintf("%s-%s %s", event.Start.Format("15:04"), event.End.Format("15:04"), event.Summary)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// activeBrowserTabs shells out to AppleScript to list the tabs open in the
+// frontmost browser window (Safari or Chrome), mirroring how
+// ScreenMonitor.GetActiveWindow queries System Events on macOS.
+func activeBrowserTabs() (string, error) {
+	script := `tell application "System Events" to set frontApp to name of first application process whose frontmost is true
+if frontApp is "Safari" then
+	tell application "Safari" to return name of every tab of front window
+else if frontApp is "Google Chrome" then
+	tell application "Google Chrome" to return name of every tab of front window
+else
+	return "{}"
+end if`
+
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list browser tabs: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// recentGitCommits returns the last 10 one-line commit summaries for the
+// repository at repoPath.
+func recentGitCommits(repoPath string) (string, error) {
+	out, err := exec.Command("git", "-C", repoPath, "log", "-10", "--oneline").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read git log: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
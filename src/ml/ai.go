@@ -1,11 +1,18 @@
 package ml
 
 import (
+	"context"
 	"fmt"
 	"image"
+	"log/slog"
 	"net/http"
-	"snitch-tui/src/core"
+	"strings"
 	"time"
+
+	"github.com/varadanvk/snitch/src/config"
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/caldav"
+	"github.com/varadanvk/snitch/src/logging"
 )
 
 // AIBackendType defines which AI backend to use
@@ -14,59 +21,612 @@ type AIBackendType int
 const (
 	BackendOllama AIBackendType = iota
 	BackendGroq
+	BackendAnthropic
+	BackendOpenAI
 )
 
-// AIAnalyzer is a unified interface for AI-powered activity analysis
+// backendFromName maps a Config.AIBackend / BackendChain entry ("groq",
+// "ollama", "anthropic", "openai") to its AIBackendType, defaulting to
+// BackendGroq for an unrecognized or empty name.
+func backendFromName(name string) AIBackendType {
+	switch name {
+	case "ollama":
+		return BackendOllama
+	case "anthropic":
+		return BackendAnthropic
+	case "openai":
+		return BackendOpenAI
+	default:
+		return BackendGroq
+	}
+}
+
+// AIAnalyzer is a unified interface for AI-powered activity analysis across
+// every pluggable Analyzer backend, falling back to heuristic analysis
+// (fallbackAnalysis) when none is available or every backend in the chain
+// fails.
 type AIAnalyzer struct {
-	ollamaAnalyzer *OllamaAnalyzer
-	groqAnalyzer   *GroqAnalyzer
-	backend        AIBackendType
+	ollamaAnalyzer    *OllamaAnalyzer
+	groqAnalyzer      *GroqAnalyzer
+	anthropicAnalyzer *AnthropicAnalyzer
+	openaiAnalyzer    *OpenAIAnalyzer
+	backend           AIBackendType
+	agents            map[string]*Agent
+	calendar          *caldav.Calendar
+
+	// backendChain, retries and timeout configure AnalyzeActivity's backend
+	// chaining: each backend in the chain is tried in order, retried up to
+	// `retries` times, and bounded by `timeout` per attempt, before moving on
+	// to the next backend. See SetBackendChain.
+	backendChain []AIBackendType
+	retries      int
+	timeout      time.Duration
+
+	// useAI caches AnyBackendAvailable() as of the last RefreshAIStatus (or
+	// construction), so AnalyzeActivity/AnalyzeActivityStream don't probe
+	// every backend's availability on every analysis cycle.
+	useAI bool
+
+	logger *slog.Logger
 }
 
-// NewAIAnalyzer creates a new AIAnalyzer with the specified backend
-func NewAIAnalyzer(backend AIBackendType, ollamaURL, ollamaModel, groqAPIKey string) *AIAnalyzer {
-	var ollamaAnalyzer *OllamaAnalyzer
+// NewAnalyzerFromConfig builds an AIAnalyzer from cfg: every backend with
+// credentials configured (Groq/Anthropic/OpenAI API key, or Ollama always)
+// is constructed up front, so a BackendChain can fall through between them
+// regardless of which one is primary, and SetBackendChain/AIBackendRetries/
+// AIBackendTimeout are wired from cfg as the defaults.
+func NewAnalyzerFromConfig(cfg *config.Config) *AIAnalyzer {
 	var groqAnalyzer *GroqAnalyzer
+	if cfg.GroqAPIKey != "" {
+		groqAnalyzer = NewGroqAnalyzer(cfg.GroqAPIKey)
+	}
+	var anthropicAnalyzer *AnthropicAnalyzer
+	if cfg.AnthropicAPIKey != "" {
+		anthropicAnalyzer = NewAnthropicAnalyzer(cfg.AnthropicAPIKey, cfg.AnthropicModel)
+	}
+	var openaiAnalyzer *OpenAIAnalyzer
+	if cfg.OpenAIAPIKey != "" {
+		openaiAnalyzer = NewOpenAIAnalyzer(cfg.OpenAIAPIKey, cfg.OpenAIModel)
+	}
 
-	if backend == BackendOllama {
-		ollamaAnalyzer = NewOllamaAnalyzer(ollamaURL, ollamaModel)
-	} else if backend == BackendGroq {
-		groqAnalyzer = NewGroqAnalyzer(groqAPIKey)
+	a := &AIAnalyzer{
+		ollamaAnalyzer:    NewOllamaAnalyzer(cfg.OllamaURL, cfg.OllamaModel),
+		groqAnalyzer:      groqAnalyzer,
+		anthropicAnalyzer: anthropicAnalyzer,
+		openaiAnalyzer:    openaiAnalyzer,
+		backend:           backendFromName(cfg.AIBackend),
+		retries:           1,
+		timeout:           20 * time.Second,
+		logger:            logging.With("ai"),
 	}
 
-	return &AIAnalyzer{
-		ollamaAnalyzer: ollamaAnalyzer,
-		groqAnalyzer:   groqAnalyzer,
-		backend:        backend,
+	chain := make([]AIBackendType, 0, len(cfg.BackendChain))
+	for _, name := range cfg.BackendChain {
+		chain = append(chain, backendFromName(name))
 	}
+	a.SetBackendChain(chain, cfg.AIBackendRetries, cfg.AIBackendTimeout)
+
+	if cfg.CalDAVURL != "" {
+		cal, err := caldav.NewCalendar(cfg.CalDAVURL, cfg.CalDAVUsername, cfg.CalDAVPassword)
+		if err != nil {
+			a.logger.Warn("caldav: failed to configure calendar", "error", err)
+		} else {
+			a.SetCalendar(cal)
+		}
+	}
+
+	a.useAI = a.AnyBackendAvailable()
+	if a.useAI {
+		a.logger.Info("AI backend available - using AI-powered analysis")
+	} else {
+		a.logger.Info("no AI backend available - using fallback heuristic analysis")
+	}
+
+	return a
 }
 
-// AnalyzeActivity analyzes a screenshot and window info to determine activity using the selected backend
-func (a *AIAnalyzer) AnalyzeActivity(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
-	switch a.backend {
+// SetBackendChain configures the ordered list of backends AnalyzeActivity
+// tries, along with per-backend retry count and per-attempt timeout. An
+// empty chain reverts to trying only the analyzer's primary backend.
+func (a *AIAnalyzer) SetBackendChain(chain []AIBackendType, retries int, timeout time.Duration) {
+	a.backendChain = chain
+	if retries > 0 {
+		a.retries = retries
+	}
+	if timeout > 0 {
+		a.timeout = timeout
+	}
+}
+
+// provider looks up the Analyzer implementation for an AIBackendType, or nil
+// if that backend wasn't configured (missing API key).
+func (a *AIAnalyzer) provider(backend AIBackendType) Analyzer {
+	switch backend {
 	case BackendOllama:
 		if a.ollamaAnalyzer == nil {
-			return core.Activity{}, fmt.Errorf("Ollama analyzer not initialized")
+			return nil
 		}
-		return a.ollamaAnalyzer.AnalyzeScreenshot(img, windowInfo, monitoringInterval)
+		return a.ollamaAnalyzer
 	case BackendGroq:
 		if a.groqAnalyzer == nil {
-			return core.Activity{}, fmt.Errorf("Groq analyzer not initialized")
+			return nil
+		}
+		return a.groqAnalyzer
+	case BackendAnthropic:
+		if a.anthropicAnalyzer == nil {
+			return nil
+		}
+		return a.anthropicAnalyzer
+	case BackendOpenAI:
+		if a.openaiAnalyzer == nil {
+			return nil
 		}
-		return a.groqAnalyzer.AnalyzeScreenshot(img, windowInfo, monitoringInterval, currentTask)
+		return a.openaiAnalyzer
 	default:
-		return core.Activity{}, fmt.Errorf("unknown AI backend")
+		return nil
+	}
+}
+
+// AnyBackendAvailable reports whether at least one backend in the
+// configured chain (or the primary backend, if no chain is set) is
+// currently usable.
+func (a *AIAnalyzer) AnyBackendAvailable() bool {
+	chain := a.backendChain
+	if len(chain) == 0 {
+		chain = []AIBackendType{a.backend}
+	}
+	for _, backend := range chain {
+		if p := a.provider(backend); p != nil && p.IsAvailable() {
+			return true
+		}
+	}
+	return false
+}
+
+// AnalyzeActivity analyzes a screenshot and window info to determine
+// activity, trying each backend in the configured chain (falling back to
+// the single primary backend when no chain is set) in order. Each backend
+// is retried up to a.retries times and bounded by a.timeout per attempt. If
+// no backend is available, or every backend in the chain fails, it falls
+// back to heuristic analysis (fallbackAnalysis) instead of returning an
+// error.
+func (a *AIAnalyzer) AnalyzeActivity(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	if !a.useAI {
+		return a.fallbackAnalysis(windowInfo, monitoringInterval), nil
+	}
+
+	activity, err := a.analyzeWithChain(img, windowInfo, monitoringInterval, currentTask)
+	if err != nil {
+		a.logger.Warn("AI analysis failed, using fallback", "error", err)
+		return a.fallbackAnalysis(windowInfo, monitoringInterval), nil
+	}
+	return activity, nil
+}
+
+// strictScreenshotAnalyzer is implemented by backends (currently only
+// Ollama) whose AnalyzeScreenshot method swallows errors and returns a
+// "fallback"-sourced Activity instead of propagating them. analyzeWithChain
+// prefers AnalyzeScreenshotStrict when available so a failed attempt
+// actually advances to the next backend in the chain instead of returning
+// early with a silently degraded result.
+type strictScreenshotAnalyzer interface {
+	AnalyzeScreenshotStrict(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error)
+}
+
+// analyzeWithChain runs the configured backend chain and returns an error
+// only once every backend in it has failed.
+func (a *AIAnalyzer) analyzeWithChain(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	chain := a.backendChain
+	if len(chain) == 0 {
+		chain = []AIBackendType{a.backend}
+	}
+
+	var lastErr error
+	for _, backend := range chain {
+		provider := a.provider(backend)
+		if provider == nil {
+			lastErr = fmt.Errorf("%s: not configured", getBackendName(backend))
+			continue
+		}
+
+		analyze := provider.AnalyzeScreenshot
+		if strict, ok := provider.(strictScreenshotAnalyzer); ok {
+			analyze = strict.AnalyzeScreenshotStrict
+		}
+
+		for attempt := 0; attempt <= a.retries; attempt++ {
+			activity, err := a.withTimeout(a.timeout, func() (core.Activity, error) {
+				return analyze(img, windowInfo, monitoringInterval, currentTask)
+			})
+			if err == nil {
+				a.logger.Debug("analyzed activity",
+					"backend", provider.Name(),
+					"app", windowInfo.Application,
+					"window_title", windowInfo.Title,
+					"productive", activity.IsProductive,
+					"score", activity.ProductivityScore,
+					"interval_s", monitoringInterval,
+				)
+				return activity, nil
+			}
+			lastErr = fmt.Errorf("%s: %w", provider.Name(), err)
+			a.logger.Warn("backend attempt failed",
+				"backend", provider.Name(),
+				"attempt", attempt,
+				"app", windowInfo.Application,
+				"error", err,
+			)
+		}
 	}
+
+	return core.Activity{}, fmt.Errorf("all backends in chain failed: %w", lastErr)
 }
 
-// SetBackend switches the backend at runtime
+// withTimeout runs fn with a bound of timeout, returning a timeout error if
+// it doesn't complete in time. A non-positive timeout disables the bound.
+func (a *AIAnalyzer) withTimeout(timeout time.Duration, fn func() (core.Activity, error)) (core.Activity, error) {
+	if timeout <= 0 {
+		return fn()
+	}
+
+	type result struct {
+		activity core.Activity
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		activity, err := fn()
+		done <- result{activity, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.activity, r.err
+	case <-time.After(timeout):
+		return core.Activity{}, fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// SetBackend switches the primary backend at runtime
 func (a *AIAnalyzer) SetBackend(backend AIBackendType) {
 	a.backend = backend
 }
 
+// SwitchBackend changes the primary backend at runtime and logs the switch.
+func (a *AIAnalyzer) SwitchBackend(backend AIBackendType) {
+	a.SetBackend(backend)
+	a.logger.Info("switched backend", "backend", getBackendName(backend))
+}
+
+// RefreshAIStatus re-checks whether any configured backend is currently
+// available and updates useAI accordingly, so AnalyzeActivity/
+// AnalyzeActivityStream pick it up on the next cycle.
+func (a *AIAnalyzer) RefreshAIStatus() {
+	a.useAI = a.AnyBackendAvailable()
+
+	if a.useAI {
+		a.logger.Info("AI is now available", "backend", getBackendName(a.backend))
+	} else {
+		a.logger.Info("AI is not available - using fallback analysis")
+	}
+}
+
+// SetStreaming enables or disables SSE streaming on the Groq backend (the
+// only backend whose streaming mode is opt-in; Ollama's streaming endpoint
+// is always used by AnalyzeActivityStream). See GroqAnalyzer.SetStreaming.
+func (a *AIAnalyzer) SetStreaming(enabled bool) {
+	if a.groqAnalyzer != nil {
+		a.groqAnalyzer.SetStreaming(enabled)
+	}
+}
+
+// AnalyzeActivityStream behaves like AnalyzeActivity but, when an AI
+// backend is available, emits incremental AnalysisEvent values through
+// onEvent as the model's response streams in, ending with an
+// AnalysisEvent{Done: true} carrying the final Activity. Only the first
+// backend in the configured chain (or the primary backend, with no chain
+// set) is tried: unlike AnalyzeActivity, there's no meaningful way to retry
+// a partially-streamed response on a different backend, so a failure here
+// falls back to heuristic analysis, the same as AnalyzeActivity does on
+// error. On failure onEvent receives two Done events: first the backend's
+// own, carrying Err, then a second one carrying the fallback Activity.
+func (a *AIAnalyzer) AnalyzeActivityStream(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string, onEvent func(AnalysisEvent)) (core.Activity, error) {
+	if !a.useAI {
+		activity := a.fallbackAnalysis(windowInfo, monitoringInterval)
+		onEvent(AnalysisEvent{Activity: activity, Done: true})
+		return activity, nil
+	}
+
+	var final core.Activity
+	var finalErr error
+	a.streamWithChain(img, windowInfo, monitoringInterval, currentTask, func(event AnalysisEvent) {
+		if event.Done {
+			final, finalErr = event.Activity, event.Err
+		}
+		onEvent(event)
+	})
+
+	if finalErr != nil {
+		a.logger.Warn("AI analysis failed, using fallback", "error", finalErr)
+		final = a.fallbackAnalysis(windowInfo, monitoringInterval)
+		onEvent(AnalysisEvent{Activity: final, Done: true})
+	}
+
+	return final, nil
+}
+
+// streamWithChain streams from the first backend in the configured chain
+// (or the primary backend, with no chain set), emitting its events through
+// onEvent unmodified, including a potential error on the final Done event.
+func (a *AIAnalyzer) streamWithChain(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string, onEvent func(AnalysisEvent)) {
+	chain := a.backendChain
+	if len(chain) == 0 {
+		chain = []AIBackendType{a.backend}
+	}
+	backend := chain[0]
+
+	switch backend {
+	case BackendGroq:
+		if a.groqAnalyzer == nil {
+			onEvent(AnalysisEvent{Done: true, Err: fmt.Errorf("groq: not configured")})
+			return
+		}
+		activity, err := a.groqAnalyzer.AnalyzeScreenshotStream(img, windowInfo, monitoringInterval, currentTask, func(chunk StreamChunkMsg) {
+			onEvent(AnalysisEvent{Delta: string(chunk)})
+		})
+		onEvent(AnalysisEvent{Activity: activity, Done: true, Err: err})
+	case BackendOllama:
+		if a.ollamaAnalyzer == nil {
+			onEvent(AnalysisEvent{Done: true, Err: fmt.Errorf("ollama: not configured")})
+			return
+		}
+		events, err := a.ollamaAnalyzer.AnalyzeScreenshotStream(context.Background(), img, windowInfo, monitoringInterval, currentTask)
+		if err != nil {
+			onEvent(AnalysisEvent{Done: true, Err: err})
+			return
+		}
+		for event := range events {
+			onEvent(event)
+		}
+	default:
+		provider := a.provider(backend)
+		if provider == nil {
+			onEvent(AnalysisEvent{Done: true, Err: fmt.Errorf("%s: not configured", getBackendName(backend))})
+			return
+		}
+		activity, err := provider.AnalyzeScreenshot(img, windowInfo, monitoringInterval, currentTask)
+		onEvent(AnalysisEvent{Activity: activity, Done: true, Err: err})
+	}
+}
+
+// GroqTokenStats returns cumulative token usage for the current session when
+// a Groq analyzer is configured, and false otherwise. This checks
+// a.groqAnalyzer directly rather than a.backend == BackendGroq, since Groq
+// can also be configured as a fallback later in BackendChain - gating on
+// the primary backend alone would hide real usage in that case.
+func (a *AIAnalyzer) GroqTokenStats() (TokenStats, bool) {
+	if a.groqAnalyzer == nil {
+		return TokenStats{}, false
+	}
+	return a.groqAnalyzer.SessionTokenStats(), true
+}
+
+// Embed requests an embedding vector for text from the Ollama backend,
+// which is the only configured backend exposing an embeddings endpoint.
+// Used by core/cluster to group activities by semantic similarity.
+func (a *AIAnalyzer) Embed(text string) ([]float64, error) {
+	if a.ollamaAnalyzer == nil {
+		return nil, fmt.Errorf("ollama backend not configured")
+	}
+	return a.ollamaAnalyzer.Embed(text)
+}
+
+// ConfigureAgents builds the three built-in agents (classifier, coach,
+// auditor), wiring the auditor's toolbox to agentCtx so it can inspect
+// activity history and the current task.
+func (a *AIAnalyzer) ConfigureAgents(agentCtx AgentContext) {
+	a.agents = BuiltinAgents(agentCtx)
+}
+
+// SelectAgent switches which agent runs on the active backend. Returns an
+// error if the name doesn't match a configured agent.
+func (a *AIAnalyzer) SelectAgent(name string) error {
+	agent, ok := a.agents[name]
+	if !ok {
+		return fmt.Errorf("unknown agent %q", name)
+	}
+
+	if a.groqAnalyzer != nil {
+		a.groqAnalyzer.SetAgent(agent)
+	}
+	if a.ollamaAnalyzer != nil {
+		a.ollamaAnalyzer.SetAgent(agent)
+	}
+	if a.anthropicAnalyzer != nil {
+		a.anthropicAnalyzer.SetAgent(agent)
+	}
+	if a.openaiAnalyzer != nil {
+		a.openaiAnalyzer.SetAgent(agent)
+	}
+	return nil
+}
+
+// SetCalendar wires a caldav.Calendar into every configured backend so
+// AnalyzeActivity's prompts include the user's current scheduled event as
+// context, and stores it on the AIAnalyzer itself so Calendar() can expose
+// it to the TUI for current-task auto-population.
+func (a *AIAnalyzer) SetCalendar(cal *caldav.Calendar) {
+	a.calendar = cal
+
+	if a.groqAnalyzer != nil {
+		a.groqAnalyzer.SetCalendar(cal)
+	}
+	if a.ollamaAnalyzer != nil {
+		a.ollamaAnalyzer.SetCalendar(cal)
+	}
+	if a.anthropicAnalyzer != nil {
+		a.anthropicAnalyzer.SetCalendar(cal)
+	}
+	if a.openaiAnalyzer != nil {
+		a.openaiAnalyzer.SetCalendar(cal)
+	}
+}
+
+// Calendar returns the configured caldav.Calendar, or nil if none is set.
+func (a *AIAnalyzer) Calendar() *caldav.Calendar {
+	return a.calendar
+}
+
+// RunAgent runs the active backend's currently selected agent on a
+// plain-text prompt, used for scheduled reports rather than per-screenshot
+// analysis.
+func (a *AIAnalyzer) RunAgent(ctx context.Context, prompt string) (string, error) {
+	if a.backend != BackendGroq || a.groqAnalyzer == nil {
+		return "", fmt.Errorf("agent tool-calling is only implemented for the Groq backend")
+	}
+	return a.groqAnalyzer.RunAgent(ctx, prompt)
+}
+
+// RunReportAgent selects agentName and runs prompt through it, then
+// restores whichever agent was active on every backend beforehand. Without
+// this, SelectAgent's switch is permanent: a report's auditor/coach agent
+// would keep classifying every subsequent screenshot for the rest of the
+// session instead of the monitor loop's usual classifier agent.
+func (a *AIAnalyzer) RunReportAgent(ctx context.Context, agentName, prompt string) (string, error) {
+	prevGroq, prevOllama, prevAnthropic, prevOpenAI := a.activeAgents()
+	defer a.restoreAgents(prevGroq, prevOllama, prevAnthropic, prevOpenAI)
+
+	if err := a.SelectAgent(agentName); err != nil {
+		return "", err
+	}
+	return a.RunAgent(ctx, prompt)
+}
+
+// activeAgents snapshots the agent currently selected on every configured
+// backend, for RunReportAgent to restore afterward.
+func (a *AIAnalyzer) activeAgents() (groq, ollama, anthropic, openai *Agent) {
+	if a.groqAnalyzer != nil {
+		groq = a.groqAnalyzer.Agent()
+	}
+	if a.ollamaAnalyzer != nil {
+		ollama = a.ollamaAnalyzer.Agent()
+	}
+	if a.anthropicAnalyzer != nil {
+		anthropic = a.anthropicAnalyzer.Agent()
+	}
+	if a.openaiAnalyzer != nil {
+		openai = a.openaiAnalyzer.Agent()
+	}
+	return
+}
+
+// restoreAgents re-applies a snapshot captured by activeAgents.
+func (a *AIAnalyzer) restoreAgents(groq, ollama, anthropic, openai *Agent) {
+	if a.groqAnalyzer != nil {
+		a.groqAnalyzer.SetAgent(groq)
+	}
+	if a.ollamaAnalyzer != nil {
+		a.ollamaAnalyzer.SetAgent(ollama)
+	}
+	if a.anthropicAnalyzer != nil {
+		a.anthropicAnalyzer.SetAgent(anthropic)
+	}
+	if a.openaiAnalyzer != nil {
+		a.openaiAnalyzer.SetAgent(openai)
+	}
+}
+
 // Helper to provide a default HTTP client (30s timeout)
 func defaultHTTPClient() *http.Client {
 	return &http.Client{
 		Timeout: 30 * time.Second,
 	}
 }
+
+// getBackendName returns a human-readable name for the backend
+func getBackendName(backend AIBackendType) string {
+	switch backend {
+	case BackendOllama:
+		return "Ollama"
+	case BackendGroq:
+		return "Groq"
+	case BackendAnthropic:
+		return "Anthropic"
+	case BackendOpenAI:
+		return "OpenAI"
+	default:
+		return "Unknown"
+	}
+}
+
+// fallbackAnalysis provides simple heuristic-based analysis for when no AI
+// backend is available or every backend in the chain fails.
+func (a *AIAnalyzer) fallbackAnalysis(windowInfo core.WindowInfo, monitoringInterval int) core.Activity {
+	appLower := strings.ToLower(windowInfo.Application)
+	titleLower := strings.ToLower(windowInfo.Title)
+
+	// Simple heuristics based on app names and window titles
+	isProductive := false
+	activity := "unknown activity"
+	category := "unknown"
+	score := 0.5
+
+	// Productive indicators
+	if strings.Contains(appLower, "code") || strings.Contains(appLower, "xcode") ||
+		strings.Contains(appLower, "terminal") || strings.Contains(appLower, "vim") ||
+		strings.Contains(titleLower, "code") || strings.Contains(titleLower, "programming") {
+		isProductive = true
+		activity = "coding/development"
+		category = "work"
+		score = 0.8
+	} else if strings.Contains(appLower, "mail") || strings.Contains(titleLower, "email") {
+		isProductive = true
+		activity = "email communication"
+		category = "work"
+		score = 0.7
+	} else if strings.Contains(appLower, "slack") || strings.Contains(appLower, "teams") ||
+		strings.Contains(appLower, "zoom") || strings.Contains(appLower, "meet") {
+		isProductive = true
+		activity = "team communication"
+		category = "work"
+		score = 0.75
+	} else if strings.Contains(appLower, "safari") || strings.Contains(appLower, "chrome") ||
+		strings.Contains(appLower, "firefox") {
+		// Browser - depends on content
+		if strings.Contains(titleLower, "github") || strings.Contains(titleLower, "stackoverflow") ||
+			strings.Contains(titleLower, "documentation") || strings.Contains(titleLower, "docs") {
+			isProductive = true
+			activity = "research/documentation"
+			category = "work"
+			score = 0.7
+		} else if strings.Contains(titleLower, "youtube") || strings.Contains(titleLower, "netflix") ||
+			strings.Contains(titleLower, "twitter") || strings.Contains(titleLower, "facebook") {
+			isProductive = false
+			activity = "entertainment/social media"
+			category = "distraction"
+			score = 0.2
+		} else {
+			activity = "web browsing"
+			category = "break"
+			score = 0.4
+		}
+	}
+
+	activityType := "distracting"
+	if isProductive {
+		activityType = "productive"
+	}
+
+	return core.Activity{
+		Timestamp:         time.Now(),
+		Type:              activityType,
+		Activity:          activity,
+		Application:       windowInfo.Application,
+		WindowTitle:       windowInfo.Title,
+		IsProductive:      isProductive,
+		Duration:          monitoringInterval,
+		ProductivityScore: score,
+		Category:          category,
+		Source:            "fallback",
+	}
+}
@@ -0,0 +1,196 @@
+package ml
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"time"
+
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/caldav"
+)
+
+// AnthropicMessage is a single message in an Anthropic /v1/messages request.
+type AnthropicMessage struct {
+	Role    string             `json:"role"`
+	Content []AnthropicContent `json:"content"`
+}
+
+// AnthropicContent is a single content block, either "text" or "image".
+type AnthropicContent struct {
+	Type   string           `json:"type"`
+	Text   string           `json:"text,omitempty"`
+	Source *AnthropicSource `json:"source,omitempty"`
+}
+
+// AnthropicSource carries a base64-encoded image, Anthropic's equivalent of
+// the OpenAI-style data URL.
+type AnthropicSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+// AnthropicRequest represents a request to Anthropic's /v1/messages endpoint.
+type AnthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []AnthropicMessage `json:"messages"`
+}
+
+// AnthropicResponse represents a (non-streamed) response from Anthropic's
+// /v1/messages endpoint.
+type AnthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+// AnthropicAnalyzer uses Anthropic's Messages API for vision analysis.
+type AnthropicAnalyzer struct {
+	apiURL   string
+	apiKey   string
+	model    string
+	client   *http.Client
+	agent    *Agent
+	calendar *caldav.Calendar
+}
+
+// NewAnthropicAnalyzer creates a new Anthropic-based vision analyzer.
+func NewAnthropicAnalyzer(apiKey, model string) *AnthropicAnalyzer {
+	if model == "" {
+		model = "claude-3-5-sonnet-20241022"
+	}
+
+	return &AnthropicAnalyzer{
+		apiURL: "https://api.anthropic.com/v1/messages",
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		agent: NewClassifierAgent(),
+	}
+}
+
+// SetAgent switches which Agent's system prompt backs subsequent requests.
+func (aa *AnthropicAnalyzer) SetAgent(agent *Agent) {
+	if agent != nil {
+		aa.agent = agent
+	}
+}
+
+// Agent returns the currently active agent.
+func (aa *AnthropicAnalyzer) Agent() *Agent {
+	return aa.agent
+}
+
+// SetCalendar wires a caldav.Calendar in so subsequent prompts include the
+// user's current scheduled event as context.
+func (aa *AnthropicAnalyzer) SetCalendar(cal *caldav.Calendar) {
+	aa.calendar = cal
+}
+
+// Name identifies this backend for provenance/logging.
+func (aa *AnthropicAnalyzer) Name() string { return "anthropic" }
+
+// IsAvailable reports whether an API key is configured.
+func (aa *AnthropicAnalyzer) IsAvailable() bool { return aa.apiKey != "" }
+
+// AnalyzeScreenshot analyzes a screenshot using Claude's vision model.
+func (aa *AnthropicAnalyzer) AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	imageB64, err := aa.imageToBase64(img)
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	scheduledContext := ""
+	if aa.calendar != nil {
+		scheduledContext = aa.calendar.ScheduledContext()
+	}
+	prompt := buildVisionPrompt(windowInfo, currentTask, scheduledContext)
+
+	response, err := aa.query(prompt, imageB64)
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to query Anthropic: %w", err)
+	}
+
+	return parseVisionResponse(response, windowInfo, monitoringInterval, "anthropic")
+}
+
+// query sends a single vision prompt (system prompt from the current agent,
+// user text + image) to Anthropic's /v1/messages endpoint and returns the
+// assistant's text content.
+func (aa *AnthropicAnalyzer) query(prompt, imageB64 string) (string, error) {
+	systemPrompt := ""
+	if aa.agent != nil {
+		systemPrompt = aa.agent.SystemPrompt
+	}
+
+	request := AnthropicRequest{
+		Model:     aa.model,
+		MaxTokens: 1024,
+		System:    systemPrompt,
+		Messages: []AnthropicMessage{
+			{
+				Role: "user",
+				Content: []AnthropicContent{
+					{
+						Type:   "image",
+						Source: &AnthropicSource{Type: "base64", MediaType: "image/png", Data: imageB64},
+					},
+					{Type: "text", Text: prompt},
+				},
+			},
+		},
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", aa.apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", aa.apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := aa.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query Anthropic: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorBody bytes.Buffer
+		errorBody.ReadFrom(resp.Body)
+		return "", fmt.Errorf("Anthropic API returned status %d: %s", resp.StatusCode, errorBody.String())
+	}
+
+	var anthropicResp AnthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&anthropicResp); err != nil {
+		return "", fmt.Errorf("failed to decode Anthropic response: %w", err)
+	}
+	if len(anthropicResp.Content) == 0 {
+		return "", fmt.Errorf("no content in Anthropic response")
+	}
+
+	return anthropicResp.Content[0].Text, nil
+}
+
+func (aa *AnthropicAnalyzer) imageToBase64(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -1,15 +1,19 @@
 package ml
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"image"
 	"image/png"
 	"net/http"
-	"snitch-tui/src/core"
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/caldav"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,7 +22,43 @@ type GroqRequest struct {
 	Model          string          `json:"model"`
 	Messages       []Message       `json:"messages"`
 	Stream         bool            `json:"stream"`
+	StreamOptions  *StreamOptions  `json:"stream_options,omitempty"`
 	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	Tools          []Tool          `json:"tools,omitempty"`
+}
+
+// StreamOptions controls streamed-response behavior. IncludeUsage asks Groq
+// to emit a final chunk carrying the prompt/completion/total token counts.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// Usage carries Groq's token accounting for a single request.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// StreamChunkMsg is a tea.Cmd-friendly message carrying one incremental
+// delta from a streamed Groq response. It has no bubbletea dependency so
+// the ml package stays UI-agnostic; AIAnalyzer.AnalyzeActivityStream wraps
+// each one in an AnalysisEvent before it reaches the TUI.
+type StreamChunkMsg string
+
+// TokenStats aggregates token usage across one or more Groq requests.
+type TokenStats struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+	Requests         int `json:"requests"`
+}
+
+func (t *TokenStats) add(u Usage) {
+	t.PromptTokens += u.PromptTokens
+	t.CompletionTokens += u.CompletionTokens
+	t.TotalTokens += u.TotalTokens
+	t.Requests++
 }
 
 type ResponseFormat struct {
@@ -26,8 +66,17 @@ type ResponseFormat struct {
 }
 
 type Message struct {
-	Role    string    `json:"role"`
-	Content []Content `json:"content"`
+	Role       string     `json:"role"`
+	Content    []Content  `json:"content,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+}
+
+// textMessage builds a plain-text message without image content, used for
+// system prompts and tool results.
+func textMessage(role, text string) Message {
+	return Message{Role: role, Content: []Content{{Type: "text", Text: text}}}
 }
 
 type Content struct {
@@ -43,27 +92,39 @@ type ImageURL struct {
 // GroqResponse follows OpenAI API format
 type GroqResponse struct {
 	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
 }
 
 type Choice struct {
 	Message struct {
-		Content string `json:"content"`
+		Content   string     `json:"content"`
+		ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 	} `json:"message"`
 }
 
-type GroqAnalyzer struct {
-	groqURL string
-	apiKey  string
-	client  *http.Client
+// GroqStreamChunk is a single `data: {...}` frame of a streamed
+// chat-completion response. The final chunk (when stream_options.
+// include_usage is set) carries Usage instead of a delta.
+type GroqStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *Usage `json:"usage,omitempty"`
 }
 
-type GroqAnalysisResult struct {
-	Activity          string  `json:"activity"`
-	IsProductive      bool    `json:"is_productive"`
-	ProductivityScore float64 `json:"productivity_score"`
-	Category          string  `json:"category"`
-	Confidence        float64 `json:"confidence"`
-	TaskAlignment     float64 `json:"task_alignment"`
+type GroqAnalyzer struct {
+	groqURL   string
+	apiKey    string
+	client    *http.Client
+	streaming bool
+	agent     *Agent
+	calendar  *caldav.Calendar
+
+	mu           sync.Mutex
+	sessionStats TokenStats
+	dailyStats   map[string]*TokenStats
 }
 
 // NewGroqAnalyzer creates a new Groq analyzer
@@ -74,13 +135,219 @@ func NewGroqAnalyzer(apiKey string) *GroqAnalyzer {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		dailyStats: make(map[string]*TokenStats),
+		agent:      NewClassifierAgent(),
+	}
+}
+
+// SetStreaming enables or disables SSE streaming for subsequent requests.
+func (ga *GroqAnalyzer) SetStreaming(enabled bool) {
+	ga.streaming = enabled
+}
+
+// SetAgent switches which Agent (system prompt, response schema, tools)
+// backs subsequent requests.
+func (ga *GroqAnalyzer) SetAgent(agent *Agent) {
+	if agent != nil {
+		ga.agent = agent
+	}
+}
+
+// Agent returns the analyzer's currently configured agent.
+func (ga *GroqAnalyzer) Agent() *Agent {
+	return ga.agent
+}
+
+// SetCalendar wires a caldav.Calendar in so subsequent prompts include the
+// user's current scheduled event as context.
+func (ga *GroqAnalyzer) SetCalendar(cal *caldav.Calendar) {
+	ga.calendar = cal
+}
+
+// Calendar returns the configured caldav.Calendar, or nil if none is set.
+func (ga *GroqAnalyzer) Calendar() *caldav.Calendar {
+	return ga.calendar
+}
+
+// scheduledContext returns the current calendar event as prompt context,
+// or "" if no calendar is configured or nothing is scheduled right now.
+func (ga *GroqAnalyzer) scheduledContext() string {
+	if ga.calendar == nil {
+		return ""
+	}
+	return ga.calendar.ScheduledContext()
+}
+
+// recordUsage folds a request's token usage into the session and daily
+// cumulative totals.
+func (ga *GroqAnalyzer) recordUsage(u Usage) {
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+
+	ga.sessionStats.add(u)
+
+	day := time.Now().Format("2006-01-02")
+	stats, ok := ga.dailyStats[day]
+	if !ok {
+		stats = &TokenStats{}
+		ga.dailyStats[day] = stats
+	}
+	stats.add(u)
+}
+
+// SessionTokenStats returns cumulative token usage since the analyzer was
+// created.
+func (ga *GroqAnalyzer) SessionTokenStats() TokenStats {
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+	return ga.sessionStats
+}
+
+// DailyTokenStats returns cumulative token usage for the given day
+// ("2006-01-02"). Returns a zero-value TokenStats if nothing was recorded.
+func (ga *GroqAnalyzer) DailyTokenStats(day string) TokenStats {
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+	if stats, ok := ga.dailyStats[day]; ok {
+		return *stats
+	}
+	return TokenStats{}
+}
+
+// maxToolIterations bounds the tool-call loop so a misbehaving model
+// (or tool) can't spin forever.
+const maxToolIterations = 5
+
+// send issues a single (non-streaming) chat completion request and records
+// its token usage.
+func (ga *GroqAnalyzer) send(request GroqRequest) (GroqResponse, error) {
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return GroqResponse{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", ga.groqURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return GroqResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+ga.apiKey)
+
+	resp, err := ga.client.Do(req)
+	if err != nil {
+		return GroqResponse{}, fmt.Errorf("failed to query Groq: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorBody bytes.Buffer
+		errorBody.ReadFrom(resp.Body)
+		return GroqResponse{}, fmt.Errorf("Groq API returned status %d: %s", resp.StatusCode, errorBody.String())
+	}
+
+	var groqResp GroqResponse
+	if err := json.NewDecoder(resp.Body).Decode(&groqResp); err != nil {
+		return GroqResponse{}, fmt.Errorf("failed to decode Groq response: %w", err)
+	}
+
+	if groqResp.Usage != nil {
+		ga.recordUsage(*groqResp.Usage)
 	}
+
+	return groqResp, nil
+}
+
+// converse drives the request/response loop for the analyzer's current
+// agent: it sends messages plus the agent's tools, and whenever the model
+// responds with tool_calls it executes the matching Go function, appends
+// the result as a role:"tool" message, and re-invokes until a final
+// message (no tool_calls) comes back.
+func (ga *GroqAnalyzer) converse(ctx context.Context, messages []Message) (string, error) {
+	agent := ga.agent
+	if agent == nil {
+		agent = NewClassifierAgent()
+	}
+
+	for i := 0; i < maxToolIterations; i++ {
+		request := GroqRequest{
+			Model:          "meta-llama/llama-4-scout-17b-16e-instruct",
+			Messages:       messages,
+			Stream:         false,
+			ResponseFormat: agent.ResponseFormat,
+			Tools:          agent.Tools,
+		}
+
+		groqResp, err := ga.send(request)
+		if err != nil {
+			return "", err
+		}
+		if len(groqResp.Choices) == 0 {
+			return "", fmt.Errorf("no choices in Groq response")
+		}
+
+		message := groqResp.Choices[0].Message
+		if len(message.ToolCalls) == 0 {
+			return message.Content, nil
+		}
+
+		messages = append(messages, Message{Role: "assistant", ToolCalls: message.ToolCalls})
+		for _, call := range message.ToolCalls {
+			result := agent.callTool(ctx, call)
+			messages = append(messages, Message{
+				Role:       "tool",
+				ToolCallID: call.ID,
+				Name:       call.Function.Name,
+				Content:    []Content{{Type: "text", Text: result}},
+			})
+		}
+	}
+
+	return "", fmt.Errorf("exceeded %d tool-call iterations without a final response", maxToolIterations)
 }
 
+// queryGroq sends a single vision prompt (system prompt from the current
+// agent, user text + image) through the tool-call loop and returns the
+// final assistant content.
 func (ga *GroqAnalyzer) queryGroq(prompt, imageB64 string) (string, error) {
-	// Create the request with vision model and JSON mode
+	messages := []Message{}
+	if ga.agent != nil && ga.agent.SystemPrompt != "" {
+		messages = append(messages, textMessage("system", ga.agent.SystemPrompt))
+	}
+	messages = append(messages, Message{
+		Role: "user",
+		Content: []Content{
+			{Type: "text", Text: prompt},
+			{Type: "image_url", ImageURL: &ImageURL{URL: "data:image/png;base64," + imageB64}},
+		},
+	})
+
+	return ga.converse(context.Background(), messages)
+}
+
+// RunAgent runs the analyzer's current agent on a plain-text prompt (no
+// screenshot), looping through any tool calls it makes. This is how the
+// coach and auditor agents are invoked for scheduled reports rather than
+// per-screenshot analysis.
+func (ga *GroqAnalyzer) RunAgent(ctx context.Context, userPrompt string) (string, error) {
+	messages := []Message{}
+	if ga.agent != nil && ga.agent.SystemPrompt != "" {
+		messages = append(messages, textMessage("system", ga.agent.SystemPrompt))
+	}
+	messages = append(messages, textMessage("user", userPrompt))
+
+	return ga.converse(ctx, messages)
+}
+
+// queryGroqStream is the streaming counterpart to queryGroq: it sets
+// Stream: true and stream_options.include_usage, then parses the
+// `text/event-stream` `data: {...}` frames incrementally, invoking onChunk
+// with each content delta as it arrives. The final chunk (once Groq
+// supports include_usage for this endpoint) carries token usage, which is
+// folded into the session/daily totals before returning.
+func (ga *GroqAnalyzer) queryGroqStream(prompt, imageB64 string, onChunk func(string)) (string, error) {
 	request := GroqRequest{
-		Model: "meta-llama/llama-4-scout-17b-16e-instruct", // Correct Groq vision model
+		Model: "meta-llama/llama-4-scout-17b-16e-instruct",
 		Messages: []Message{
 			{
 				Role: "user",
@@ -98,7 +365,8 @@ func (ga *GroqAnalyzer) queryGroq(prompt, imageB64 string) (string, error) {
 				},
 			},
 		},
-		Stream: false,
+		Stream:        true,
+		StreamOptions: &StreamOptions{IncludeUsage: true},
 		ResponseFormat: &ResponseFormat{
 			Type: "json_object",
 		},
@@ -109,17 +377,15 @@ func (ga *GroqAnalyzer) queryGroq(prompt, imageB64 string) (string, error) {
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Create HTTP request
 	req, err := http.NewRequest("POST", ga.groqURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+ga.apiKey)
+	req.Header.Set("Accept", "text/event-stream")
 
-	// Make the request
 	resp, err := ga.client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to query Groq: %w", err)
@@ -127,23 +393,47 @@ func (ga *GroqAnalyzer) queryGroq(prompt, imageB64 string) (string, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		// Read the error response for debugging
 		var errorBody bytes.Buffer
 		errorBody.ReadFrom(resp.Body)
 		return "", fmt.Errorf("Groq API returned status %d: %s", resp.StatusCode, errorBody.String())
 	}
 
-	var groqResp GroqResponse
-	err = json.NewDecoder(resp.Body).Decode(&groqResp)
-	if err != nil {
-		return "", fmt.Errorf("failed to decode Groq response: %w", err)
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload == "[DONE]" {
+			break
+		}
+
+		var chunk GroqStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			continue
+		}
+
+		if chunk.Usage != nil {
+			ga.recordUsage(*chunk.Usage)
+		}
+
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			delta := chunk.Choices[0].Delta.Content
+			full.WriteString(delta)
+			if onChunk != nil {
+				onChunk(delta)
+			}
+		}
 	}
 
-	if len(groqResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices in Groq response")
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read Groq stream: %w", err)
 	}
 
-	return groqResp.Choices[0].Message.Content, nil
+	return full.String(), nil
 }
 
 func (ga *GroqAnalyzer) imageToBase64(img image.Image) (string, error) {
@@ -155,89 +445,52 @@ func (ga *GroqAnalyzer) imageToBase64(img image.Image) (string, error) {
 	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
 }
 
+// Name identifies this backend for provenance/logging.
+func (ga *GroqAnalyzer) Name() string { return "groq" }
+
+// IsAvailable reports whether an API key is configured.
+func (ga *GroqAnalyzer) IsAvailable() bool { return ga.apiKey != "" }
+
 func (ga *GroqAnalyzer) AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
 	imageB64, err := ga.imageToBase64(img)
 	if err != nil {
 		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
 	}
 
-	taskContext := ""
-	if currentTask != "" {
-		taskContext = fmt.Sprintf("\nCurrent task: %s", currentTask)
-	}
-
-	prompt := fmt.Sprintf(`Analyze this screenshot and determine what activity the user is doing. 
-
-Current application: %s
-Window title: %s%s
-
-Please respond with ONLY a JSON object containing:
-{
-  "activity": "brief description of what the user is doing",
-  "is_productive": true/false,
-  "productivity_score": 0.0-1.0,
-  "category": "work/break/distraction",
-  "confidence": 0.0-1.0,
-  "task_alignment": 0.0-1.0
-}
-
-Focus on identifying:
-- Code editing, development work, documentation
-- Communication (email, messaging, meetings)
-- Research, reading technical content
-- Social media, entertainment, gaming
-- Shopping, news browsing
-
-%sConsider how well the current activity aligns with the stated task when setting task_alignment and productivity scores.
-
-Be concise and accurate. Return ONLY the JSON, no other text.`,
-		windowInfo.Application,
-		windowInfo.Title,
-		taskContext,
-		func() string {
-			if currentTask != "" {
-				return fmt.Sprintf("IMPORTANT: The user should be working on: %s. ", currentTask)
-			}
-			return ""
-		}())
+	prompt := buildVisionPrompt(windowInfo, currentTask, ga.scheduledContext())
 
 	response, err := ga.queryGroq(prompt, imageB64)
 	if err != nil {
 		return core.Activity{}, fmt.Errorf("failed to query Groq: %w", err)
 	}
 
-	// Parse the JSON response
-	var result GroqAnalysisResult
-
-	// Try to extract JSON from the response
-	jsonStart := strings.Index(response, "{")
-	jsonEnd := strings.LastIndex(response, "}") + 1
+	return parseVisionResponse(response, windowInfo, monitoringInterval, "groq")
+}
 
-	if jsonStart == -1 || jsonEnd <= jsonStart {
-		return core.Activity{}, fmt.Errorf("no JSON found in response: %s", response)
+// AnalyzeScreenshotStream behaves like AnalyzeScreenshot but, when streaming
+// is enabled, emits each response delta on onChunk (wired by
+// AIAnalyzer.AnalyzeActivityStream to an AnalysisEvent) so the TUI can render
+// partial analysis as it arrives instead of blocking on the full HTTP call.
+func (ga *GroqAnalyzer) AnalyzeScreenshotStream(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string, onChunk func(StreamChunkMsg)) (core.Activity, error) {
+	if !ga.streaming {
+		return ga.AnalyzeScreenshot(img, windowInfo, monitoringInterval, currentTask)
 	}
 
-	jsonStr := response[jsonStart:jsonEnd]
-
-	err = json.Unmarshal([]byte(jsonStr), &result)
+	imageB64, err := ga.imageToBase64(img)
 	if err != nil {
-		return core.Activity{}, fmt.Errorf("failed to unmarshal Groq response: %w, response: %s", err, jsonStr)
+		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
 	}
 
-	activityType := "distracting"
-	if result.IsProductive {
-		activityType = "productive"
+	prompt := buildVisionPrompt(windowInfo, currentTask, ga.scheduledContext())
+
+	response, err := ga.queryGroqStream(prompt, imageB64, func(delta string) {
+		if onChunk != nil {
+			onChunk(StreamChunkMsg(delta))
+		}
+	})
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to query Groq: %w", err)
 	}
 
-	return core.Activity{
-		Timestamp:         time.Now(),
-		Type:              activityType,
-		Activity:          result.Activity,
-		Application:       windowInfo.Application,
-		WindowTitle:       windowInfo.Title,
-		IsProductive:      result.IsProductive,
-		Duration:          monitoringInterval,
-		ProductivityScore: result.ProductivityScore,
-		Category:          result.Category,
-	}, nil
+	return parseVisionResponse(response, windowInfo, monitoringInterval, "groq")
 }
@@ -2,30 +2,56 @@ package ml
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	"image/png"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
-	"snitch-tui/src/core"
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/caldav"
 )
 
-// OllamaRequest represents a request to Ollama API
-type OllamaRequest struct {
-	Model  string   `json:"model"`
-	Prompt string   `json:"prompt"`
-	Images []string `json:"images,omitempty"`
-	Stream bool     `json:"stream"`
+// OllamaChatMessage is a single message in an Ollama /api/chat request.
+type OllamaChatMessage struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
 }
 
-// OllamaResponse represents a response from Ollama API
-type OllamaResponse struct {
-	Response string `json:"response"`
-	Done     bool   `json:"done"`
+// OllamaChatRequest represents a request to Ollama's /api/chat endpoint,
+// mirroring the role/message shape used by the Groq path so agents behave
+// identically across backends.
+type OllamaChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []OllamaChatMessage `json:"messages"`
+	Stream   bool                `json:"stream"`
+}
+
+// OllamaChatResponse represents a (non-streamed) response from Ollama's
+// /api/chat endpoint. When Stream is true, Ollama instead sends a sequence
+// of these as newline-delimited JSON, one per token, with Done set only on
+// the final object.
+type OllamaChatResponse struct {
+	Message OllamaChatMessage `json:"message"`
+	Done    bool              `json:"done"`
+}
+
+// AnalysisEvent is one step of a streamed screenshot analysis: either an
+// incremental token (Delta) or, on the final event (Done), the fully
+// parsed Activity. Err is set if the stream failed partway through, in
+// which case Done is also true and no further events follow.
+type AnalysisEvent struct {
+	Delta    string
+	Activity core.Activity
+	Done     bool
+	Err      error
 }
 
 // OllamaAnalyzer uses Ollama API for real image analysis
@@ -33,6 +59,8 @@ type OllamaAnalyzer struct {
 	ollamaURL string
 	model     string
 	client    *http.Client
+	agent     *Agent
+	calendar  *caldav.Calendar
 }
 
 // NewOllamaAnalyzer creates a new Ollama-based analyzer
@@ -50,56 +78,73 @@ func NewOllamaAnalyzer(ollamaURL, model string) *OllamaAnalyzer {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		agent: NewClassifierAgent(),
 	}
 }
 
-// AnalyzeScreenshot analyzes a screenshot using Ollama's vision model
-func (oa *OllamaAnalyzer) AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int) (core.Activity, error) {
-	// Convert image to base64
-	imageB64, err := oa.imageToBase64(img)
-	if err != nil {
-		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
+// SetAgent switches which Agent's system prompt backs subsequent requests,
+// mirroring GroqAnalyzer.SetAgent so the two backends behave identically.
+func (oa *OllamaAnalyzer) SetAgent(agent *Agent) {
+	if agent != nil {
+		oa.agent = agent
 	}
+}
 
-	// Create prompt for productivity analysis
-	prompt := fmt.Sprintf(`Analyze this screenshot and determine what activity the user is doing. 
+// Agent returns the currently active agent.
+func (oa *OllamaAnalyzer) Agent() *Agent {
+	return oa.agent
+}
 
-Current application: %s
-Window title: %s
+// SetCalendar wires a caldav.Calendar in so subsequent prompts include the
+// user's current scheduled event as context.
+func (oa *OllamaAnalyzer) SetCalendar(cal *caldav.Calendar) {
+	oa.calendar = cal
+}
 
-Please respond with a JSON object containing:
-{
-  "activity": "brief description of what the user is doing",
-  "is_productive": true/false,
-  "productivity_score": 0.0-1.0,
-  "category": "work/break/distraction",
-  "confidence": 0.0-1.0
+// scheduledContext returns the current calendar event as prompt context,
+// or "" if no calendar is configured or nothing is scheduled right now.
+func (oa *OllamaAnalyzer) scheduledContext() string {
+	if oa.calendar == nil {
+		return ""
+	}
+	return oa.calendar.ScheduledContext()
 }
 
-Focus on identifying:
-- Code editing, development work, documentation
-- Communication (email, messaging, meetings)
-- Research, reading technical content
-- Social media, entertainment, gaming
-- Shopping, news browsing
+// Name identifies this backend for provenance/logging.
+func (oa *OllamaAnalyzer) Name() string { return "ollama" }
 
-Be concise and accurate.`, windowInfo.Application, windowInfo.Title)
+// IsAvailable reports whether Ollama is reachable and has the model loaded.
+func (oa *OllamaAnalyzer) IsAvailable() bool { return oa.IsOllamaAvailable() }
 
-	// Make request to Ollama
-	response, err := oa.queryOllama(prompt, imageB64)
+// AnalyzeScreenshot analyzes a screenshot using Ollama's vision model,
+// falling back to simple heuristics if Ollama is unreachable or its
+// response can't be parsed.
+func (oa *OllamaAnalyzer) AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	activity, err := oa.AnalyzeScreenshotStrict(img, windowInfo, monitoringInterval, currentTask)
 	if err != nil {
-		// Fallback to simple heuristic if Ollama fails
 		return oa.fallbackAnalysis(windowInfo, monitoringInterval), nil
 	}
+	return activity, nil
+}
 
-	// Parse response
-	activity, err := oa.parseOllamaResponse(response, windowInfo, monitoringInterval)
+// AnalyzeScreenshotStrict behaves like AnalyzeScreenshot but propagates
+// errors instead of silently falling back to heuristics, so callers doing
+// their own backend chaining (see AIAnalyzer.AnalyzeActivity) can tell
+// whether Ollama actually produced a result.
+func (oa *OllamaAnalyzer) AnalyzeScreenshotStrict(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	imageB64, err := oa.imageToBase64(img)
 	if err != nil {
-		// Fallback if parsing fails
-		return oa.fallbackAnalysis(windowInfo, monitoringInterval), nil
+		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
 	}
 
-	return activity, nil
+	prompt := buildVisionPrompt(windowInfo, currentTask, oa.scheduledContext())
+
+	response, err := oa.queryOllama(prompt, imageB64)
+	if err != nil {
+		return core.Activity{}, err
+	}
+
+	return parseVisionResponse(response, windowInfo, monitoringInterval, "ollama")
 }
 
 // imageToBase64 converts an image to base64 string
@@ -113,13 +158,24 @@ func (oa *OllamaAnalyzer) imageToBase64(img image.Image) (string, error) {
 	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
 }
 
-// queryOllama sends a request to Ollama API
+// queryOllama sends a chat request to Ollama's /api/chat endpoint, using
+// the analyzer's current agent as the system prompt so the Ollama backend
+// shares the same prompt plumbing as Groq.
 func (oa *OllamaAnalyzer) queryOllama(prompt, imageB64 string) (string, error) {
-	request := OllamaRequest{
-		Model:  oa.model,
-		Prompt: prompt,
-		Images: []string{imageB64},
-		Stream: false,
+	messages := []OllamaChatMessage{}
+	if oa.agent != nil && oa.agent.SystemPrompt != "" {
+		messages = append(messages, OllamaChatMessage{Role: "system", Content: oa.agent.SystemPrompt})
+	}
+	messages = append(messages, OllamaChatMessage{
+		Role:    "user",
+		Content: prompt,
+		Images:  []string{imageB64},
+	})
+
+	request := OllamaChatRequest{
+		Model:    oa.model,
+		Messages: messages,
+		Stream:   false,
 	}
 
 	jsonData, err := json.Marshal(request)
@@ -128,7 +184,7 @@ func (oa *OllamaAnalyzer) queryOllama(prompt, imageB64 string) (string, error) {
 	}
 
 	resp, err := oa.client.Post(
-		oa.ollamaURL+"/api/generate",
+		oa.ollamaURL+"/api/chat",
 		"application/json",
 		bytes.NewBuffer(jsonData),
 	)
@@ -141,58 +197,158 @@ func (oa *OllamaAnalyzer) queryOllama(prompt, imageB64 string) (string, error) {
 		return "", fmt.Errorf("Ollama API returned status %d", resp.StatusCode)
 	}
 
-	var ollamaResp OllamaResponse
+	var ollamaResp OllamaChatResponse
 	err = json.NewDecoder(resp.Body).Decode(&ollamaResp)
 	if err != nil {
 		return "", err
 	}
 
-	return ollamaResp.Response, nil
+	return ollamaResp.Message.Content, nil
+}
+
+// OllamaEmbeddingsRequest represents a request to Ollama's /api/embeddings
+// endpoint.
+type OllamaEmbeddingsRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+// OllamaEmbeddingsResponse represents a response from Ollama's
+// /api/embeddings endpoint.
+type OllamaEmbeddingsResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+// Embed requests an embedding vector for text from Ollama's
+// /api/embeddings endpoint, using the same model configured for vision
+// analysis. Used by core/cluster to group activities by semantic
+// similarity instead of their noisy free-text description alone.
+func (oa *OllamaAnalyzer) Embed(text string) ([]float64, error) {
+	jsonData, err := json.Marshal(OllamaEmbeddingsRequest{Model: oa.model, Prompt: text})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := oa.client.Post(oa.ollamaURL+"/api/embeddings", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to request embedding: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Ollama embeddings API returned status %d", resp.StatusCode)
+	}
+
+	var embeddingResp OllamaEmbeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddingResp); err != nil {
+		return nil, err
+	}
+	return embeddingResp.Embedding, nil
 }
 
-// OllamaAnalysisResult represents the parsed result from Ollama
-type OllamaAnalysisResult struct {
-	Activity          string  `json:"activity"`
-	IsProductive      bool    `json:"is_productive"`
-	ProductivityScore float64 `json:"productivity_score"`
-	Category          string  `json:"category"`
-	Confidence        float64 `json:"confidence"`
+// AnalyzeScreenshotStream behaves like AnalyzeScreenshotStrict but streams
+// the model's response as it's generated instead of blocking until Ollama
+// finishes the whole completion. It sends Stream: true, decodes the
+// newline-delimited JSON chunks Ollama replies with, and publishes one
+// AnalysisEvent per token on the returned channel. The final event carries
+// the parsed Activity with Done set; an error at any point is reported as
+// a terminal event with Err set. Callers should derive ctx from the
+// monitoring loop's current screenshot cycle so a new cycle's call can
+// cancel a still-running previous one rather than letting them race.
+func (oa *OllamaAnalyzer) AnalyzeScreenshotStream(ctx context.Context, img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (<-chan AnalysisEvent, error) {
+	imageB64, err := oa.imageToBase64(img)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	prompt := buildVisionPrompt(windowInfo, currentTask, oa.scheduledContext())
+
+	events := make(chan AnalysisEvent)
+	go func() {
+		defer close(events)
+
+		var full strings.Builder
+		err := oa.queryOllamaStream(ctx, prompt, imageB64, func(delta string) {
+			full.WriteString(delta)
+			select {
+			case events <- AnalysisEvent{Delta: delta}:
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			events <- AnalysisEvent{Done: true, Err: err}
+			return
+		}
+
+		activity, err := parseVisionResponse(full.String(), windowInfo, monitoringInterval, "ollama")
+		events <- AnalysisEvent{Activity: activity, Done: true, Err: err}
+	}()
+
+	return events, nil
 }
 
-// parseOllamaResponse parses Ollama's response into an Activity
-func (oa *OllamaAnalyzer) parseOllamaResponse(response string, windowInfo core.WindowInfo, monitoringInterval int) (core.Activity, error) {
-	// Try to extract JSON from the response
-	jsonStart := strings.Index(response, "{")
-	jsonEnd := strings.LastIndex(response, "}") + 1
+// queryOllamaStream is the streaming counterpart to queryOllama: it sets
+// Stream: true and decodes the response body as a sequence of
+// newline-delimited OllamaChatResponse objects (rather than a single JSON
+// object), invoking onChunk with each token's content as it arrives. It
+// stops early if ctx is cancelled, which aborts the underlying HTTP
+// request via context.Context.
+func (oa *OllamaAnalyzer) queryOllamaStream(ctx context.Context, prompt, imageB64 string, onChunk func(string)) error {
+	messages := []OllamaChatMessage{}
+	if oa.agent != nil && oa.agent.SystemPrompt != "" {
+		messages = append(messages, OllamaChatMessage{Role: "system", Content: oa.agent.SystemPrompt})
+	}
+	messages = append(messages, OllamaChatMessage{
+		Role:    "user",
+		Content: prompt,
+		Images:  []string{imageB64},
+	})
+
+	request := OllamaChatRequest{
+		Model:    oa.model,
+		Messages: messages,
+		Stream:   true,
+	}
 
-	if jsonStart == -1 || jsonEnd <= jsonStart {
-		return core.Activity{}, fmt.Errorf("no JSON found in response")
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return err
 	}
 
-	jsonStr := response[jsonStart:jsonEnd]
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oa.ollamaURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
 
-	var result OllamaAnalysisResult
-	err := json.Unmarshal([]byte(jsonStr), &result)
+	resp, err := oa.client.Do(req)
 	if err != nil {
-		return core.Activity{}, fmt.Errorf("failed to parse JSON: %w", err)
+		return fmt.Errorf("failed to query Ollama: %w", err)
 	}
+	defer resp.Body.Close()
 
-	activityType := "distracting"
-	if result.IsProductive {
-		activityType = "productive"
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Ollama API returned status %d", resp.StatusCode)
 	}
 
-	return core.Activity{
-		Timestamp:         time.Now(),
-		Type:              activityType,
-		Activity:          result.Activity,
-		Application:       windowInfo.Application,
-		WindowTitle:       windowInfo.Title,
-		IsProductive:      result.IsProductive,
-		Duration:          monitoringInterval,
-		ProductivityScore: result.ProductivityScore,
-		Category:          result.Category,
-	}, nil
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var chunk OllamaChatResponse
+		if err := decoder.Decode(&chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode Ollama stream: %w", err)
+		}
+
+		if chunk.Message.Content != "" && onChunk != nil {
+			onChunk(chunk.Message.Content)
+		}
+
+		if chunk.Done {
+			return nil
+		}
+	}
 }
 
 // fallbackAnalysis provides simple heuristic-based analysis when Ollama fails
@@ -262,6 +418,7 @@ func (oa *OllamaAnalyzer) fallbackAnalysis(windowInfo core.WindowInfo, monitorin
 		Duration:          monitoringInterval,
 		ProductivityScore: score,
 		Category:          category,
+		Source:            "fallback",
 	}
 }
 
@@ -0,0 +1,156 @@
+package ml
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"time"
+
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/caldav"
+)
+
+// OpenAIAnalyzer uses OpenAI's chat-completions API for vision analysis. Its
+// request/response shape is the same one Groq mirrors (Message/Content/
+// ImageURL/GroqRequest/GroqResponse), so it reuses those types rather than
+// redeclaring them.
+type OpenAIAnalyzer struct {
+	apiURL   string
+	apiKey   string
+	model    string
+	client   *http.Client
+	agent    *Agent
+	calendar *caldav.Calendar
+}
+
+// NewOpenAIAnalyzer creates a new OpenAI-based vision analyzer.
+func NewOpenAIAnalyzer(apiKey, model string) *OpenAIAnalyzer {
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+
+	return &OpenAIAnalyzer{
+		apiURL: "https://api.openai.com/v1/chat/completions",
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		agent: NewClassifierAgent(),
+	}
+}
+
+// SetAgent switches which Agent's system prompt backs subsequent requests.
+func (oa *OpenAIAnalyzer) SetAgent(agent *Agent) {
+	if agent != nil {
+		oa.agent = agent
+	}
+}
+
+// Agent returns the currently active agent.
+func (oa *OpenAIAnalyzer) Agent() *Agent {
+	return oa.agent
+}
+
+// SetCalendar wires a caldav.Calendar in so subsequent prompts include the
+// user's current scheduled event as context.
+func (oa *OpenAIAnalyzer) SetCalendar(cal *caldav.Calendar) {
+	oa.calendar = cal
+}
+
+// Name identifies this backend for provenance/logging.
+func (oa *OpenAIAnalyzer) Name() string { return "openai" }
+
+// IsAvailable reports whether an API key is configured.
+func (oa *OpenAIAnalyzer) IsAvailable() bool { return oa.apiKey != "" }
+
+// AnalyzeScreenshot analyzes a screenshot using OpenAI's vision model.
+func (oa *OpenAIAnalyzer) AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error) {
+	imageB64, err := oa.imageToBase64(img)
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	scheduledContext := ""
+	if oa.calendar != nil {
+		scheduledContext = oa.calendar.ScheduledContext()
+	}
+	prompt := buildVisionPrompt(windowInfo, currentTask, scheduledContext)
+
+	response, err := oa.query(prompt, imageB64)
+	if err != nil {
+		return core.Activity{}, fmt.Errorf("failed to query OpenAI: %w", err)
+	}
+
+	return parseVisionResponse(response, windowInfo, monitoringInterval, "openai")
+}
+
+// query sends a single vision prompt (system prompt from the current agent,
+// user text + image) to OpenAI's chat-completions endpoint and returns the
+// assistant's message content.
+func (oa *OpenAIAnalyzer) query(prompt, imageB64 string) (string, error) {
+	messages := []Message{}
+	if oa.agent != nil && oa.agent.SystemPrompt != "" {
+		messages = append(messages, textMessage("system", oa.agent.SystemPrompt))
+	}
+	messages = append(messages, Message{
+		Role: "user",
+		Content: []Content{
+			{Type: "text", Text: prompt},
+			{Type: "image_url", ImageURL: &ImageURL{URL: "data:image/png;base64," + imageB64}},
+		},
+	})
+
+	request := GroqRequest{
+		Model:          oa.model,
+		Messages:       messages,
+		Stream:         false,
+		ResponseFormat: &ResponseFormat{Type: "json_object"},
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", oa.apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+oa.apiKey)
+
+	resp, err := oa.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query OpenAI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorBody bytes.Buffer
+		errorBody.ReadFrom(resp.Body)
+		return "", fmt.Errorf("OpenAI API returned status %d: %s", resp.StatusCode, errorBody.String())
+	}
+
+	var openaiResp GroqResponse
+	if err := json.NewDecoder(resp.Body).Decode(&openaiResp); err != nil {
+		return "", fmt.Errorf("failed to decode OpenAI response: %w", err)
+	}
+	if len(openaiResp.Choices) == 0 {
+		return "", fmt.Errorf("no choices in OpenAI response")
+	}
+
+	return openaiResp.Choices[0].Message.Content, nil
+}
+
+func (oa *OpenAIAnalyzer) imageToBase64(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
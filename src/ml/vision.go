@@ -0,0 +1,115 @@
+package ml
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// Analyzer is implemented by every AI vision provider pluggable into
+// AIAnalyzer (Groq, Ollama, Anthropic, OpenAI): it can analyze a
+// screenshot, report whether it's currently usable, and identify itself for
+// provenance (core.Activity.Source) and logging.
+type Analyzer interface {
+	AnalyzeScreenshot(img image.Image, windowInfo core.WindowInfo, monitoringInterval int, currentTask string) (core.Activity, error)
+	IsAvailable() bool
+	Name() string
+}
+
+// VisionResult is the common JSON shape every vision backend is prompted to
+// return, so they can share one prompt, one parser, and one core.Activity
+// mapping instead of each inventing its own schema.
+type VisionResult struct {
+	Activity          string  `json:"activity"`
+	IsProductive      bool    `json:"is_productive"`
+	ProductivityScore float64 `json:"productivity_score"`
+	Category          string  `json:"category"`
+	Confidence        float64 `json:"confidence"`
+	TaskAlignment     float64 `json:"task_alignment"`
+}
+
+// buildVisionPrompt renders the vision prompt shared by every backend.
+// scheduledContext, when non-empty (see caldav.Calendar.ScheduledContext),
+// is spliced in alongside the window info so the model can weigh the
+// user's actual calendar instead of only their stated task.
+func buildVisionPrompt(windowInfo core.WindowInfo, currentTask, scheduledContext string) string {
+	taskContext := ""
+	if currentTask != "" {
+		taskContext = fmt.Sprintf("\nCurrent task: %s", currentTask)
+	}
+
+	taskEmphasis := ""
+	if currentTask != "" {
+		taskEmphasis = fmt.Sprintf("IMPORTANT: The user should be working on: %s. ", currentTask)
+	}
+
+	scheduleContext := ""
+	if scheduledContext != "" {
+		scheduleContext = fmt.Sprintf("\n%s", scheduledContext)
+	}
+
+	return fmt.Sprintf(`Analyze this screenshot and determine what activity the user is doing.
+
+Current application: %s
+Window title: %s%s%s
+
+Please respond with ONLY a JSON object containing:
+{
+  "activity": "brief description of what the user is doing",
+  "is_productive": true/false,
+  "productivity_score": 0.0-1.0,
+  "category": "work/break/distraction",
+  "confidence": 0.0-1.0,
+  "task_alignment": 0.0-1.0
+}
+
+Focus on identifying:
+- Code editing, development work, documentation
+- Communication (email, messaging, meetings)
+- Research, reading technical content
+- Social media, entertainment, gaming
+- Shopping, news browsing
+
+%sConsider how well the current activity aligns with the stated task when setting task_alignment and productivity scores.
+
+Be concise and accurate. Return ONLY the JSON, no other text.`,
+		windowInfo.Application, windowInfo.Title, taskContext, scheduleContext, taskEmphasis)
+}
+
+// parseVisionResponse extracts the JSON object from a (possibly chatty)
+// model response and turns it into a core.Activity tagged with source.
+func parseVisionResponse(response string, windowInfo core.WindowInfo, monitoringInterval int, source string) (core.Activity, error) {
+	jsonStart := strings.Index(response, "{")
+	jsonEnd := strings.LastIndex(response, "}") + 1
+	if jsonStart == -1 || jsonEnd <= jsonStart {
+		return core.Activity{}, fmt.Errorf("no JSON found in response: %s", response)
+	}
+	jsonStr := response[jsonStart:jsonEnd]
+
+	var result VisionResult
+	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
+		return core.Activity{}, fmt.Errorf("failed to unmarshal %s response: %w, response: %s", source, err, jsonStr)
+	}
+
+	activityType := "distracting"
+	if result.IsProductive {
+		activityType = "productive"
+	}
+
+	return core.Activity{
+		Timestamp:         time.Now(),
+		Type:              activityType,
+		Activity:          result.Activity,
+		Application:       windowInfo.Application,
+		WindowTitle:       windowInfo.Title,
+		IsProductive:      result.IsProductive,
+		Duration:          monitoringInterval,
+		ProductivityScore: result.ProductivityScore,
+		Category:          result.Category,
+		Source:            source,
+	}, nil
+}
@@ -0,0 +1,463 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"net/smtp"
+	"net/url"
+
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/logging"
+
+	"github.com/gen2brain/beeep"
+)
+
+// platformNotifier sends a desktop notification through whatever
+// mechanism is native to the current OS, layered on top of beeep's
+// cross-platform baseline. See notify_darwin.go and notify_other.go.
+type platformNotifier interface {
+	Notify(title, message string) error
+}
+
+// NotificationMessages holds the message variants used for the desktop
+// backend, keyed by category.
+var NotificationMessages = map[string][]string{
+	"distracted": {
+		"Distraction detected - consider refocusing on your current task",
+		"Non-productive activity identified",
+		"Activity may not align with your current goals",
+		"Consider returning to your primary task",
+	},
+	"productive": {
+		"Productive activity detected - great work!",
+		"Good focus on productive tasks",
+		"Productive session in progress",
+		"Maintaining good work habits",
+	},
+	"reminder": {
+		"Task reminder - how is your progress?",
+		"Checking in on your current task",
+		"Time to review your current activity",
+		"Task status check",
+	},
+}
+
+// activityMessage renders an activity as a human-readable notification
+// body, reused by every backend below.
+func activityMessage(activity core.Activity) string {
+	var messages []string
+	if activity.IsProductive {
+		messages = NotificationMessages["productive"]
+	} else {
+		messages = NotificationMessages["distracted"]
+	}
+	message := messages[rand.Intn(len(messages))]
+
+	return fmt.Sprintf("%s\n\nDetected: %s in %s", message, activity.Activity, activity.Application)
+}
+
+// DesktopNotifier sends a local desktop notification via beeep, plus
+// whatever extra native mechanism the current OS's platformNotifier adds.
+type DesktopNotifier struct {
+	notifier platformNotifier
+	logger   *slog.Logger
+}
+
+// NewDesktopNotifier creates a DesktopNotifier, selecting the
+// platformNotifier for the current OS (see newPlatformNotifier).
+func NewDesktopNotifier() *DesktopNotifier {
+	return &DesktopNotifier{notifier: newPlatformNotifier(), logger: logging.With("notifications")}
+}
+
+func (d *DesktopNotifier) Name() string { return "desktop" }
+
+// Send displays activity via beeep (cross-platform) and the OS-native
+// notifier, logging but not failing on the beeep leg so the native one
+// still gets a chance to fire.
+func (d *DesktopNotifier) Send(activity core.Activity) error {
+	title := "Snitch Productivity Monitor"
+	message := activityMessage(activity)
+
+	if err := beeep.Notify(title, message, ""); err != nil {
+		d.logger.Warn("beeep notification failed", "error", err)
+	}
+
+	return d.notifier.Notify(title, message)
+}
+
+// SendText displays subject/body via beeep and the OS-native notifier,
+// the same way Send does for activities.
+func (d *DesktopNotifier) SendText(subject, body string) error {
+	if err := beeep.Notify(subject, body, ""); err != nil {
+		d.logger.Warn("beeep notification failed", "error", err)
+	}
+	return d.notifier.Notify(subject, body)
+}
+
+// WebhookNotifier POSTs a JSON activity payload to an arbitrary URL.
+type WebhookNotifier struct {
+	url string
+}
+
+// NewWebhookNotifier creates a WebhookNotifier targeting url.
+func NewWebhookNotifier(url string) (*WebhookNotifier, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook notifier requires WebhookURL to be set")
+	}
+	return &WebhookNotifier{url: url}, nil
+}
+
+func (w *WebhookNotifier) Name() string { return "webhook" }
+
+func (w *WebhookNotifier) Send(activity core.Activity) error {
+	payload, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity: %w", err)
+	}
+
+	resp, err := http.Post(w.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendText POSTs subject/body as a JSON payload, the same way Send does
+// for activities.
+func (w *WebhookNotifier) SendText(subject, body string) error {
+	payload, err := json.Marshal(map[string]string{"subject": subject, "body": body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	resp, err := http.Post(w.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackChannelNotifier posts activity updates to a Slack Incoming Webhook.
+type SlackChannelNotifier struct {
+	webhookURL string
+}
+
+// NewSlackChannelNotifier creates a SlackChannelNotifier targeting webhookURL.
+func NewSlackChannelNotifier(webhookURL string) (*SlackChannelNotifier, error) {
+	if webhookURL == "" {
+		return nil, fmt.Errorf("slack notifier requires SlackWebhookURL to be set")
+	}
+	return &SlackChannelNotifier{webhookURL: webhookURL}, nil
+}
+
+func (s *SlackChannelNotifier) Name() string { return "slack" }
+
+func (s *SlackChannelNotifier) Send(activity core.Activity) error {
+	payload, err := json.Marshal(map[string]string{"text": activityMessage(activity)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	resp, err := http.Post(s.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendText posts "subject\n\nbody" to the Slack webhook, the same way
+// Send does for activities.
+func (s *SlackChannelNotifier) SendText(subject, body string) error {
+	payload, err := json.Marshal(map[string]string{"text": subject + "\n\n" + body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	resp, err := http.Post(s.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DiscordChannelNotifier posts activity updates to a Discord webhook.
+type DiscordChannelNotifier struct {
+	webhookURL string
+}
+
+// NewDiscordChannelNotifier creates a DiscordChannelNotifier targeting webhookURL.
+func NewDiscordChannelNotifier(webhookURL string) (*DiscordChannelNotifier, error) {
+	if webhookURL == "" {
+		return nil, fmt.Errorf("discord notifier requires DiscordWebhookURL to be set")
+	}
+	return &DiscordChannelNotifier{webhookURL: webhookURL}, nil
+}
+
+func (d *DiscordChannelNotifier) Name() string { return "discord" }
+
+func (d *DiscordChannelNotifier) Send(activity core.Activity) error {
+	payload, err := json.Marshal(map[string]string{"content": activityMessage(activity)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord payload: %w", err)
+	}
+
+	resp, err := http.Post(d.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post to discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendText posts "subject\n\nbody" to the Discord webhook, the same way
+// Send does for activities.
+func (d *DiscordChannelNotifier) SendText(subject, body string) error {
+	payload, err := json.Marshal(map[string]string{"content": subject + "\n\n" + body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord payload: %w", err)
+	}
+
+	resp, err := http.Post(d.webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post to discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// PushoverNotifier sends activity updates through Pushover.
+type PushoverNotifier struct {
+	token, user string
+}
+
+// NewPushoverNotifier creates a PushoverNotifier using the given
+// application token and user/group key.
+func NewPushoverNotifier(token, user string) (*PushoverNotifier, error) {
+	if token == "" || user == "" {
+		return nil, fmt.Errorf("pushover notifier requires PushoverToken and PushoverUser to be set")
+	}
+	return &PushoverNotifier{token: token, user: user}, nil
+}
+
+func (p *PushoverNotifier) Name() string { return "pushover" }
+
+func (p *PushoverNotifier) Send(activity core.Activity) error {
+	return p.post("Snitch Productivity Monitor", activityMessage(activity))
+}
+
+// SendText posts subject/body to Pushover, the same way Send does for
+// activities.
+func (p *PushoverNotifier) SendText(subject, body string) error {
+	return p.post(subject, body)
+}
+
+func (p *PushoverNotifier) post(title, message string) error {
+	form := url.Values{
+		"token":   {p.token},
+		"user":    {p.user},
+		"title":   {title},
+		"message": {message},
+	}
+
+	resp, err := http.PostForm("https://api.pushover.net/1/messages.json", form)
+	if err != nil {
+		return fmt.Errorf("failed to post to pushover: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pushover returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TelegramNotifier sends activity updates through a Telegram bot.
+type TelegramNotifier struct {
+	botToken, chatID string
+}
+
+// NewTelegramNotifier creates a TelegramNotifier posting as botToken into
+// chatID.
+func NewTelegramNotifier(botToken, chatID string) (*TelegramNotifier, error) {
+	if botToken == "" || chatID == "" {
+		return nil, fmt.Errorf("telegram notifier requires TelegramBotToken and TelegramChatID to be set")
+	}
+	return &TelegramNotifier{botToken: botToken, chatID: chatID}, nil
+}
+
+func (t *TelegramNotifier) Name() string { return "telegram" }
+
+func (t *TelegramNotifier) Send(activity core.Activity) error {
+	return t.post(activityMessage(activity))
+}
+
+// SendText sends "subject\n\nbody" to the Telegram chat, the same way
+// Send does for activities.
+func (t *TelegramNotifier) SendText(subject, body string) error {
+	return t.post(subject + "\n\n" + body)
+}
+
+func (t *TelegramNotifier) post(text string) error {
+	form := url.Values{
+		"chat_id": {t.chatID},
+		"text":    {text},
+	}
+
+	resp, err := http.PostForm(fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.botToken), form)
+	if err != nil {
+		return fmt.Errorf("failed to post to telegram: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMSNotifier sends activity updates as texts via Twilio, to every number
+// in recipients - the accountability buddies this is usually configured
+// for want everyone to get the same nudge, not just the first one.
+type SMSNotifier struct {
+	sid, authToken, from string
+	recipients           []string
+}
+
+// NewSMSNotifier creates an SMSNotifier sending from the Twilio number
+// `from` to every number in recipients.
+func NewSMSNotifier(sid, authToken, from string, recipients []string) (*SMSNotifier, error) {
+	if sid == "" || authToken == "" || from == "" {
+		return nil, fmt.Errorf("sms notifier requires TwilioSID, TwilioAuthToken and TwilioFrom to be set")
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("sms notifier requires at least one number in AccountabilityBuddies")
+	}
+	return &SMSNotifier{sid: sid, authToken: authToken, from: from, recipients: recipients}, nil
+}
+
+func (s *SMSNotifier) Name() string { return "sms" }
+
+func (s *SMSNotifier) Send(activity core.Activity) error {
+	return s.sendAll(activityMessage(activity))
+}
+
+// SendText texts "subject\n\nbody" to every recipient, the same way Send
+// does for activities.
+func (s *SMSNotifier) SendText(subject, body string) error {
+	return s.sendAll(subject + "\n\n" + body)
+}
+
+// sendAll texts body to every configured buddy, aggregating (rather than
+// stopping at) individual send failures so one bad number doesn't swallow
+// the rest, mirroring how MultiNotifier treats its own backends.
+func (s *SMSNotifier) sendAll(body string) error {
+	var errs []error
+	for _, to := range s.recipients {
+		if err := s.send(to, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", to, err))
+		}
+	}
+	if len(errs) > 0 {
+		return joinErrs(errs)
+	}
+	return nil
+}
+
+func (s *SMSNotifier) send(to, body string) error {
+	apiURL := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", s.sid)
+	form := url.Values{
+		"From": {s.from},
+		"To":   {to},
+		"Body": {body},
+	}
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build twilio request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.sid, s.authToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to twilio: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier sends activity updates over SMTP.
+type EmailNotifier struct {
+	host, user, password, to string
+}
+
+// NewEmailNotifier creates an EmailNotifier using the given SMTP
+// credentials and recipient address.
+func NewEmailNotifier(host, user, password, to string) (*EmailNotifier, error) {
+	if host == "" || to == "" {
+		return nil, fmt.Errorf("smtp notifier requires SMTPHost and SMTPTo to be set")
+	}
+	return &EmailNotifier{host: host, user: user, password: password, to: to}, nil
+}
+
+func (e *EmailNotifier) Name() string { return "smtp" }
+
+func (e *EmailNotifier) Send(activity core.Activity) error {
+	subject := "Snitch Productivity Monitor"
+	body := activityMessage(activity)
+	msg := []byte(fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", e.to, subject, body))
+
+	auth := smtp.PlainAuth("", e.user, e.password, e.host)
+	if err := smtp.SendMail(e.host+":587", auth, e.user, []string{e.to}, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// SendText emails subject/body directly, rather than the rolled
+// activityMessage used by Send.
+func (e *EmailNotifier) SendText(subject, body string) error {
+	msg := []byte(fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", e.to, subject, body))
+
+	auth := smtp.PlainAuth("", e.user, e.password, e.host)
+	if err := smtp.SendMail(e.host+":587", auth, e.user, []string{e.to}, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -2,109 +2,94 @@ package notifications
 
 import (
 	"fmt"
-	"log"
-	"math/rand"
-	"os/exec"
+	"log/slog"
 	"time"
 
-	"snitch-tui/src/core"
-
-	"github.com/gen2brain/beeep"
+	"github.com/varadanvk/snitch/src/config"
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/logging"
 )
 
-// Professional notification messages
-var NotificationMessages = map[string][]string{
-	"distracted": {
-		"Distraction detected - consider refocusing on your current task",
-		"Non-productive activity identified",
-		"Activity may not align with your current goals",
-		"Consider returning to your primary task",
-	},
-	"productive": {
-		"Productive activity detected - great work!",
-		"Good focus on productive tasks",
-		"Productive session in progress",
-		"Maintaining good work habits",
-	},
-	"reminder": {
-		"Task reminder - how is your progress?",
-		"Checking in on your current task",
-		"Time to review your current activity",
-		"Task status check",
-	},
-}
-
-// Manager handles sending notifications
+// Manager rate-limits activity notifications, then dispatches each one to
+// every backend enabled in cfg.Notifiers via a MultiNotifier.
 type Manager struct {
 	lastNotification time.Time
 	minInterval      time.Duration
+	multi            *MultiNotifier
+	logger           *slog.Logger
 }
 
-// NewManager creates a new notification manager
-func NewManager(minInterval time.Duration) *Manager {
+// NewManager creates a new notification manager, building the
+// MultiNotifier for every backend named in cfg.Notifiers.
+func NewManager(minInterval time.Duration, cfg *config.Config) (*Manager, error) {
+	multi, err := NewMultiNotifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Manager{
 		minInterval: minInterval,
-	}
+		multi:       multi,
+		logger:      logging.With("notifications"),
+	}, nil
 }
 
-// SendActivityNotification sends a notification based on activity
+// SendActivityNotification rate-limits, then dispatches activity to every
+// enabled backend, aggregating (rather than stopping at) individual
+// backend errors so one broken webhook doesn't silence the others.
 func (nm *Manager) SendActivityNotification(activity core.Activity) error {
-	// Rate limiting
 	if time.Since(nm.lastNotification) < nm.minInterval {
+		nm.logger.Debug("skipped notification, rate limited", "app", activity.Application, "productive", activity.IsProductive)
 		return nil // Skip notification due to rate limiting
 	}
 
-	var messages []string
-	if activity.IsProductive {
-		messages = NotificationMessages["productive"]
-	} else {
-		messages = NotificationMessages["distracted"]
-	}
-
-	// Pick a random professional message
-	message := messages[rand.Intn(len(messages))]
-
-	// Add context
-	contextMessage := fmt.Sprintf("%s\n\nDetected: %s in %s",
-		message, activity.Activity, activity.Application)
+	nm.lastNotification = time.Now()
 
-	// Send notification
-	err := nm.sendNotification("Snitch Productivity Monitor", contextMessage)
-	if err != nil {
-		return fmt.Errorf("failed to send notification: %w", err)
+	if errs := nm.multi.Send(activity); len(errs) > 0 {
+		err := fmt.Errorf("notifications: %d of %d backends failed: %w", len(errs), len(nm.multi.notifiers), joinErrs(errs))
+		nm.logger.Warn("activity notification failed", "app", activity.Application, "error", err)
+		return err
 	}
-
-	nm.lastNotification = time.Now()
+	nm.logger.Debug("sent activity notification", "app", activity.Application, "productive", activity.IsProductive, "score", activity.ProductivityScore)
 	return nil
 }
 
-// SendCustomNotification sends a custom notification
-func (nm *Manager) SendCustomNotification(title, message string) error {
-	return nm.sendNotification(title, message)
-}
-
-// sendNotification sends a notification using multiple methods
-func (nm *Manager) sendNotification(title, message string) error {
-	// Try beeep first (cross-platform)
-	err := beeep.Notify(title, message, "")
-	if err != nil {
-		log.Printf("beeep notification failed: %v", err)
-	}
-
-	// Also try macOS native notification with sound
-	cmd := exec.Command("osascript", "-e",
-		fmt.Sprintf(`display notification "%s" with title "%s" sound name "Ping"`,
-			message, title))
-
-	if err := cmd.Run(); err != nil {
-		log.Printf("macOS notification failed: %v", err)
+// SendText dispatches subject/body (e.g. a rendered reports.Report) to
+// every configured backend that implements TextNotifier, ignoring rate
+// limiting since session reports are sent at most a few times a day.
+func (nm *Manager) SendText(subject, body string) error {
+	if errs := nm.multi.SendText(subject, body); len(errs) > 0 {
+		err := fmt.Errorf("notifications: %d of %d backends failed: %w", len(errs), len(nm.multi.notifiers), joinErrs(errs))
+		nm.logger.Warn("text notification failed", "subject", subject, "error", err)
 		return err
 	}
-
+	nm.logger.Debug("sent text notification", "subject", subject)
 	return nil
 }
 
+// GetNames returns the configured backend names, in cfg.Notifiers order,
+// for the startup banner.
+func (nm *Manager) GetNames() []string {
+	return nm.multi.GetNames()
+}
+
 // SetMinInterval updates the minimum interval between notifications
 func (nm *Manager) SetMinInterval(interval time.Duration) {
 	nm.minInterval = interval
 }
+
+// Test sends a synthetic notification through every configured backend
+// that supports it, for `snitch notify test`.
+func (nm *Manager) Test() []TestResult {
+	return nm.multi.Test()
+}
+
+// joinErrs renders a slice of per-backend errors as a single error for
+// logging, since Go's error interface doesn't support returning a list.
+func joinErrs(errs []error) error {
+	msg := errs[0].Error()
+	for _, err := range errs[1:] {
+		msg += "; " + err.Error()
+	}
+	return fmt.Errorf("%s", msg)
+}
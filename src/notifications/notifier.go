@@ -0,0 +1,154 @@
+package notifications
+
+import (
+	"fmt"
+
+	"github.com/varadanvk/snitch/src/config"
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// Notifier is implemented by every notification backend the
+// MultiNotifier can dispatch an activity to.
+type Notifier interface {
+	Send(activity core.Activity) error
+	Name() string
+}
+
+// TextNotifier is implemented by backends that can also deliver an
+// arbitrary subject/body message (e.g. a rendered reports.Report) rather
+// than just a core.Activity update. Not every Notifier supports this, so
+// MultiNotifier.SendText type-asserts for it and skips backends that don't.
+type TextNotifier interface {
+	SendText(subject, body string) error
+}
+
+// MultiNotifier fans an activity out to every registered Notifier,
+// aggregating (rather than stopping at) individual backend errors so one
+// broken destination doesn't silence the rest.
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+// NewMultiNotifier builds a MultiNotifier from cfg.Notifiers, constructing
+// only the backends named there, in that order. If cfg.AccountabilityBuddies
+// is non-empty, "sms" is implicitly added even when absent from Notifiers,
+// so configuring a buddy is enough on its own to start texting them.
+func NewMultiNotifier(cfg *config.Config) (*MultiNotifier, error) {
+	names := cfg.Notifiers
+	if len(cfg.AccountabilityBuddies) > 0 && !contains(names, "sms") {
+		names = append(append([]string{}, names...), "sms")
+	}
+
+	mn := &MultiNotifier{}
+	for _, name := range names {
+		n, err := newNotifierByName(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("notifications: failed to configure %q: %w", name, err)
+		}
+		mn.notifiers = append(mn.notifiers, n)
+	}
+	return mn, nil
+}
+
+func contains(names []string, target string) bool {
+	for _, name := range names {
+		if name == target {
+			return true
+		}
+	}
+	return false
+}
+
+// newNotifierByName resolves a single cfg.Notifiers entry to its backend.
+//
+// This sticks to the name-based registry chunk2-2 settled on rather than
+// reviving chunk0-1's shoutrrr-style URL scheme (discord://, sms://, ...):
+// that design already won out across the config and every other backend
+// here, and running two parallel notifier subsystems would be worse than
+// picking one. osascript:// isn't listed separately because "desktop"
+// already wraps the native macOS notifier (see notify_darwin.go).
+func newNotifierByName(name string, cfg *config.Config) (Notifier, error) {
+	switch name {
+	case "desktop":
+		return NewDesktopNotifier(), nil
+	case "webhook":
+		return NewWebhookNotifier(cfg.WebhookURL)
+	case "slack":
+		return NewSlackChannelNotifier(cfg.SlackWebhookURL)
+	case "discord":
+		return NewDiscordChannelNotifier(cfg.DiscordWebhookURL)
+	case "smtp":
+		return NewEmailNotifier(cfg.SMTPHost, cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPTo)
+	case "pushover":
+		return NewPushoverNotifier(cfg.PushoverToken, cfg.PushoverUser)
+	case "telegram":
+		return NewTelegramNotifier(cfg.TelegramBotToken, cfg.TelegramChatID)
+	case "sms":
+		return NewSMSNotifier(cfg.TwilioSID, cfg.TwilioAuthToken, cfg.TwilioFrom, cfg.AccountabilityBuddies)
+	default:
+		return nil, fmt.Errorf("unknown notifier backend %q", name)
+	}
+}
+
+// Send dispatches activity to every registered backend, collecting errors
+// instead of stopping at the first failure.
+func (mn *MultiNotifier) Send(activity core.Activity) []error {
+	var errs []error
+	for _, n := range mn.notifiers {
+		if err := n.Send(activity); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errs
+}
+
+// SendText dispatches subject/body to every registered backend that
+// implements TextNotifier, collecting errors instead of stopping at the
+// first failure, the same way Send does for activities.
+func (mn *MultiNotifier) SendText(subject, body string) []error {
+	var errs []error
+	for _, n := range mn.notifiers {
+		tn, ok := n.(TextNotifier)
+		if !ok {
+			continue
+		}
+		if err := tn.SendText(subject, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errs
+}
+
+// GetNames returns the configured backend names, in cfg.Notifiers order,
+// for the startup banner.
+func (mn *MultiNotifier) GetNames() []string {
+	names := make([]string, len(mn.notifiers))
+	for i, n := range mn.notifiers {
+		names[i] = n.Name()
+	}
+	return names
+}
+
+// TestResult is the per-backend outcome reported by `snitch notify test`.
+type TestResult struct {
+	Name string
+	Err  error
+}
+
+// Test sends a synthetic message through every registered backend that
+// implements TextNotifier, reporting success/failure per backend instead
+// of stopping at the first error.
+func (mn *MultiNotifier) Test() []TestResult {
+	results := make([]TestResult, 0, len(mn.notifiers))
+	for _, n := range mn.notifiers {
+		tn, ok := n.(TextNotifier)
+		if !ok {
+			continue
+		}
+		results = append(results, TestResult{
+			Name: n.Name(),
+			Err:  tn.SendText("Snitch notification test", "Verifying notification delivery"),
+		})
+	}
+	return results
+}
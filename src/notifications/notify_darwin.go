@@ -0,0 +1,24 @@
+//go:build darwin
+
+package notifications
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// macNotifier additionally fires a native macOS notification (with sound)
+// via osascript, on top of the beeep notification DesktopNotifier always sends.
+type macNotifier struct{}
+
+// newPlatformNotifier returns the platformNotifier for the current OS.
+func newPlatformNotifier() platformNotifier {
+	return macNotifier{}
+}
+
+// Notify displays a native macOS notification with a "Ping" sound.
+func (macNotifier) Notify(title, message string) error {
+	cmd := exec.Command("osascript", "-e",
+		fmt.Sprintf(`display notification "%s" with title "%s" sound name "Ping"`, message, title))
+	return cmd.Run()
+}
@@ -0,0 +1,18 @@
+//go:build !darwin
+
+package notifications
+
+// noopNotifier is the platformNotifier for Linux and Windows: beeep
+// already sends a native notification on both (notify-send and the WinRT
+// toast bridge, respectively), so there's nothing extra to add.
+type noopNotifier struct{}
+
+// newPlatformNotifier returns the platformNotifier for the current OS.
+func newPlatformNotifier() platformNotifier {
+	return noopNotifier{}
+}
+
+// Notify is a no-op; beeep.Notify already covers this OS natively.
+func (noopNotifier) Notify(title, message string) error {
+	return nil
+}
@@ -0,0 +1,210 @@
+package reports
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/varadanvk/snitch/src/config"
+)
+
+//go:embed templates/markdown.tmpl templates/html.tmpl
+var defaultTemplates embed.FS
+
+// Formatter renders a Report into one of the reports package's supported
+// output formats, selected via the `--format` flag or cfg.ReportsFormat.
+type Formatter interface {
+	Format(r Report) (string, error)
+	Name() string
+}
+
+// NewFormatter resolves a format name to its Formatter, defaulting to
+// markdown when name is empty. Recognized names are "markdown", "json",
+// "html", and "porcelain".
+func NewFormatter(name string, cfg *config.Config) (Formatter, error) {
+	switch name {
+	case "", "markdown":
+		return &MarkdownFormatter{TemplatePath: cfg.ReportsMarkdownTemplatePath}, nil
+	case "json":
+		return JSONFormatter{}, nil
+	case "html":
+		return &HTMLFormatter{TemplatePath: cfg.ReportsHTMLTemplatePath}, nil
+	case "porcelain":
+		return PorcelainFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("reports: unknown format %q", name)
+	}
+}
+
+// funcMap is shared by the markdown and html templates.
+func funcMap() texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"percent":  func(p float64) string { return fmt.Sprintf("%.0f%%", p*100) },
+		"duration": durationString,
+		"bar":      asciiBar,
+	}
+}
+
+// asciiBar renders p (0-1) as a fixed-width "####......" sparkline, the
+// same "#"/"." convention the timeline view in src/ui uses for its
+// productivity bars.
+const barWidth = 20
+
+func asciiBar(p float64) string {
+	if p < 0 {
+		p = 0
+	}
+	if p > 1 {
+		p = 1
+	}
+	filled := int(p * barWidth)
+	return strings.Repeat("#", filled) + strings.Repeat(".", barWidth-filled)
+}
+
+// durationString formats a count of seconds as "1h 12m" (or "45m", or
+// "30s" for sub-minute durations).
+func durationString(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+	if m > 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// templateSource reads path if set, else falls back to the template
+// embedded at embeddedName, so a report can be reskinned via config
+// without recompiling.
+func templateSource(path, embeddedName string) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reports: failed to read template %q: %w", path, err)
+		}
+		return string(data), nil
+	}
+
+	data, err := defaultTemplates.ReadFile(embeddedName)
+	if err != nil {
+		return "", fmt.Errorf("reports: failed to read embedded template %q: %w", embeddedName, err)
+	}
+	return string(data), nil
+}
+
+// MarkdownFormatter renders a human-readable Markdown summary, the
+// default format for both `snitch report` and auto-sent reports.
+type MarkdownFormatter struct {
+	// TemplatePath overrides the embedded default at templates/markdown.tmpl.
+	TemplatePath string
+}
+
+func (f *MarkdownFormatter) Name() string { return "markdown" }
+
+func (f *MarkdownFormatter) Format(r Report) (string, error) {
+	src, err := templateSource(f.TemplatePath, "templates/markdown.tmpl")
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := texttemplate.New("markdown").Funcs(funcMap()).Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("reports: failed to parse markdown template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("reports: failed to render markdown template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// HTMLFormatter renders a self-contained styled HTML page suitable for
+// emailing or sharing.
+type HTMLFormatter struct {
+	// TemplatePath overrides the embedded default at templates/html.tmpl.
+	TemplatePath string
+}
+
+func (f *HTMLFormatter) Name() string { return "html" }
+
+func (f *HTMLFormatter) Format(r Report) (string, error) {
+	src, err := templateSource(f.TemplatePath, "templates/html.tmpl")
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := htmltemplate.New("html").Funcs(htmltemplate.FuncMap(funcMap())).Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("reports: failed to parse html template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("reports: failed to render html template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// JSONFormatter renders a Report as indented JSON, a stable
+// machine-readable schema for downstream tooling.
+type JSONFormatter struct{}
+
+func (JSONFormatter) Name() string { return "json" }
+
+func (JSONFormatter) Format(r Report) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("reports: failed to marshal JSON report: %w", err)
+	}
+	return string(data), nil
+}
+
+// PorcelainFormatter renders a Report as stable `key=value` lines, one per
+// metric, modelled on Watchtower's porcelain output: safe to grep or parse
+// in scripts across releases.
+type PorcelainFormatter struct{}
+
+func (PorcelainFormatter) Name() string { return "porcelain" }
+
+func (PorcelainFormatter) Format(r Report) (string, error) {
+	lines := []string{
+		fmt.Sprintf("snitch.session.start=%s", r.Start.Format(time.RFC3339)),
+		fmt.Sprintf("snitch.session.end=%s", r.End.Format(time.RFC3339)),
+		fmt.Sprintf("snitch.session.productive_seconds=%d", r.ProductiveSeconds),
+		fmt.Sprintf("snitch.session.distracting_seconds=%d", r.DistractingSeconds),
+		fmt.Sprintf("snitch.session.productive_pct=%.2f", r.ProductivePct()),
+	}
+
+	for _, bucket := range r.AppBuckets {
+		lines = append(lines, fmt.Sprintf("snitch.session.app.%s.seconds=%d", slug(bucket.Application), bucket.Seconds))
+	}
+
+	if r.Narrative != "" {
+		lines = append(lines, fmt.Sprintf("snitch.session.narrative=%s", r.Narrative))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// slug lowercases s and replaces spaces/slashes with underscores, so an
+// application name like "Sublime Text" becomes a safe porcelain key
+// segment ("sublime_text").
+func slug(s string) string {
+	s = strings.ToLower(s)
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '/' {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,123 @@
+// Package reports builds end-of-session productivity reports from
+// core.ActivityHistory and renders them in one of several pluggable output
+// formats (see formats.go), so the same Report can be printed to a
+// terminal, emailed as HTML, piped into a script as porcelain, or archived
+// as JSON without any of this package's analysis code changing.
+package reports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// AppBucket is one row of a per-application time breakdown.
+type AppBucket struct {
+	Application string
+	Seconds     int
+}
+
+// TimelineEntry is a single chronological activity in a Report's Timeline.
+type TimelineEntry struct {
+	At           time.Time
+	Application  string
+	Activity     string
+	IsProductive bool
+}
+
+// Report summarizes a monitoring session (or an arbitrary time window) for
+// `snitch report` and for auto-sent end-of-session reports.
+type Report struct {
+	Start time.Time
+	End   time.Time
+
+	ProductiveSeconds  int
+	DistractingSeconds int
+
+	// AppBuckets is every application's total time in the window, sorted
+	// by descending Seconds.
+	AppBuckets []AppBucket
+
+	// TopDistractions is the subset of AppBuckets whose time came from
+	// non-productive activities, also sorted by descending Seconds.
+	TopDistractions []AppBucket
+
+	// TaskEvidence lists the Activity description of every productive
+	// "work"-category activity, verbatim, as rough evidence of what got
+	// done during the session.
+	TaskEvidence []string
+
+	// Timeline is every activity in the window, in chronological order.
+	Timeline []TimelineEntry
+
+	// Narrative is an optional LLM-generated summary of the session,
+	// filled in by the caller (e.g. via ml.AIAnalyzer.RunReportAgent) since this
+	// package has no AI dependency of its own.
+	Narrative string
+}
+
+// TotalSeconds returns the combined productive and distracting time
+// covered by the report.
+func (r Report) TotalSeconds() int {
+	return r.ProductiveSeconds + r.DistractingSeconds
+}
+
+// ProductivePct returns the fraction (0-1) of TotalSeconds that was
+// productive, or 0 for a session with no recorded time.
+func (r Report) ProductivePct() float64 {
+	total := r.TotalSeconds()
+	if total == 0 {
+		return 0
+	}
+	return float64(r.ProductiveSeconds) / float64(total)
+}
+
+// Generate builds a Report from every activity in history timestamped
+// between start and end (inclusive).
+func Generate(history *core.ActivityHistory, start, end time.Time) Report {
+	r := Report{Start: start, End: end}
+
+	appSeconds := make(map[string]int)
+	distractionSeconds := make(map[string]int)
+
+	for _, activity := range history.GetAll() {
+		if activity.Timestamp.Before(start) || activity.Timestamp.After(end) {
+			continue
+		}
+
+		if activity.IsProductive {
+			r.ProductiveSeconds += activity.Duration
+			if activity.Category == "work" {
+				r.TaskEvidence = append(r.TaskEvidence, activity.Activity)
+			}
+		} else {
+			r.DistractingSeconds += activity.Duration
+			distractionSeconds[activity.Application] += activity.Duration
+		}
+
+		appSeconds[activity.Application] += activity.Duration
+		r.Timeline = append(r.Timeline, TimelineEntry{
+			At:           activity.Timestamp,
+			Application:  activity.Application,
+			Activity:     activity.Activity,
+			IsProductive: activity.IsProductive,
+		})
+	}
+
+	r.AppBuckets = bucketsFromMap(appSeconds)
+	r.TopDistractions = bucketsFromMap(distractionSeconds)
+
+	return r
+}
+
+// bucketsFromMap turns a per-application seconds map into AppBuckets
+// sorted by descending Seconds.
+func bucketsFromMap(m map[string]int) []AppBucket {
+	buckets := make([]AppBucket, 0, len(m))
+	for app, seconds := range m {
+		buckets = append(buckets, AppBucket{Application: app, Seconds: seconds})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Seconds > buckets[j].Seconds })
+	return buckets
+}
@@ -0,0 +1,38 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// clockFormats are tried in order when parsing a bare clock time like "9am"
+// or "9:30pm" for the `--since` flag.
+var clockFormats = []string{"3pm", "3:04pm", "15:04", "15"}
+
+// ParseSince interprets the CLI `--since` flag relative to now, accepting
+// either a duration ("2h", "90m") meaning "that long ago", or a clock time
+// ("9am", "9:30pm") meaning "today at that time" (rolled back a day if it
+// would otherwise be in the future).
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("reports: --since value must not be empty")
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range clockFormats {
+		if t, err := time.Parse(layout, strings.ToLower(value)); err == nil {
+			since := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+			if since.After(now) {
+				since = since.AddDate(0, 0, -1)
+			}
+			return since, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("reports: could not parse --since value %q (expected a duration like \"2h\" or a clock time like \"9am\")", value)
+}
@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSinceDuration(t *testing.T) {
+	now := time.Date(2026, 7, 29, 15, 0, 0, 0, time.UTC)
+
+	got, err := ParseSince("2h", now)
+	if err != nil {
+		t.Fatalf("ParseSince returned error: %v", err)
+	}
+	want := now.Add(-2 * time.Hour)
+	if !got.Equal(want) {
+		t.Errorf("ParseSince(%q) = %v, want %v", "2h", got, want)
+	}
+}
+
+func TestParseSinceClockTimeEarlierToday(t *testing.T) {
+	now := time.Date(2026, 7, 29, 15, 0, 0, 0, time.Local)
+
+	got, err := ParseSince("9am", now)
+	if err != nil {
+		t.Fatalf("ParseSince returned error: %v", err)
+	}
+	want := time.Date(2026, 7, 29, 9, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("ParseSince(%q) = %v, want %v", "9am", got, want)
+	}
+}
+
+func TestParseSinceClockTimeLaterTodayRollsBackADay(t *testing.T) {
+	now := time.Date(2026, 7, 29, 8, 0, 0, 0, time.Local)
+
+	got, err := ParseSince("3pm", now)
+	if err != nil {
+		t.Fatalf("ParseSince returned error: %v", err)
+	}
+	want := time.Date(2026, 7, 28, 15, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("ParseSince(%q) = %v, want %v", "3pm", got, want)
+	}
+}
+
+func TestParseSinceEmptyValue(t *testing.T) {
+	if _, err := ParseSince("  ", time.Now()); err == nil {
+		t.Error("expected an error for an empty --since value")
+	}
+}
+
+func TestParseSinceUnparseableValue(t *testing.T) {
+	if _, err := ParseSince("whenever", time.Now()); err == nil {
+		t.Error("expected an error for an unparseable --since value")
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/ml"
+)
+
+// msgAnalysisChunk carries one incremental token delta from the live
+// analysis stream subscribed via waitForAnalysisEvent.
+type msgAnalysisChunk struct{ delta string }
+
+// msgAnalysisEnd marks the end of one streamed analysis cycle, carrying the
+// final classified activity.
+type msgAnalysisEnd struct{ activity core.Activity }
+
+// msgAnalysisError reports a streamed analysis cycle that failed.
+type msgAnalysisError struct{ err error }
+
+// waitForAnalysisEvent returns a tea.Cmd that blocks on the next
+// ml.AnalysisEvent from ch, translating it into the matching msgAnalysis*
+// message. app.go's Update re-issues this after every one of those
+// messages, so the analysis view keeps listening for as long as it's open.
+func waitForAnalysisEvent(ch <-chan ml.AnalysisEvent) tea.Cmd {
+	return func() tea.Msg {
+		event := <-ch
+		switch {
+		case event.Err != nil:
+			return msgAnalysisError{err: event.Err}
+		case event.Done:
+			return msgAnalysisEnd{activity: event.Activity}
+		default:
+			return msgAnalysisChunk{delta: event.Delta}
+		}
+	}
+}
+
+// resetAnalysisStream (re)subscribes to a fresh analysis event channel and
+// clears the accumulated text/metrics, for entering the analysis view. It
+// unsubscribes any previous channel first so re-entering the view doesn't
+// leak the one from a prior visit.
+func (m *Model) resetAnalysisStream() {
+	m.stopAnalysisStream()
+
+	ch, unsubscribe := m.core.SubscribeAnalysis()
+	m.analysisChan = ch
+	m.analysisUnsubscribe = unsubscribe
+	m.analysisText = ""
+	m.analysisTokens = 0
+	m.analysisStart = time.Now()
+	m.analysisWaiting = true
+	m.analysisErr = nil
+}
+
+// stopAnalysisStream unsubscribes the current analysis channel, if any,
+// for leaving the analysis view. Safe to call even if no stream is open.
+func (m *Model) stopAnalysisStream() {
+	if m.analysisUnsubscribe != nil {
+		m.analysisUnsubscribe()
+		m.analysisUnsubscribe = nil
+	}
+}
+
+// updateAnalysis handles the live analysis view navigation. The streamed
+// content itself is applied in app.go's Update, since msgAnalysisChunk/End/
+// Error aren't tea.KeyMsg and never reach here.
+func (m *Model) updateAnalysis(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keyBack):
+		m.stopAnalysisStream()
+		m.currentView = "main"
+		m.status = "Snitch AI Productivity Monitor - Ready"
+	case key.Matches(msg, keyQuit):
+		m.stopAnalysisStream()
+		return m, tea.Quit
+	case key.Matches(msg, helpKey):
+		m.showHelp = true
+	}
+	return m, nil
+}
+
+// viewAnalysis renders the most recent (or in-flight) AI classification as
+// it streams in, with a spinner while waiting on the next cycle's first
+// chunk, a blinking cursor once text is arriving, and a metrics footer
+// mirroring a chat client's token/elapsed/tokens-per-second readout.
+func (m *Model) viewAnalysis() string {
+	headerStyle := GetHeaderStyle()
+	labelStyle := GetLabelStyle()
+	statStyle := GetStatStyle()
+	errorStyle := GetErrorStyle()
+
+	s := headerStyle.Render("LIVE AI ANALYSIS") + "\n\n"
+
+	if m.analysisErr != nil {
+		s += errorStyle.Render(fmt.Sprintf("[ERROR] %v", m.analysisErr)) + "\n\n"
+	}
+
+	s += labelStyle.Render("Reasoning:") + "\n"
+	if m.analysisWaiting {
+		s += m.spinner.View() + " waiting for next analysis cycle...\n\n"
+	} else {
+		s += m.analysisText + m.analysisCursor.View() + "\n\n"
+	}
+
+	elapsed := time.Since(m.analysisStart)
+	tokensPerSec := 0.0
+	if elapsed > 0 {
+		tokensPerSec = float64(m.analysisTokens) / elapsed.Seconds()
+	}
+	s += statStyle.Render(fmt.Sprintf("Tokens: %d  Elapsed: %s  Tokens/sec: %.1f",
+		m.analysisTokens, elapsed.Round(time.Second), tokensPerSec)) + "\n\n"
+
+	s += m.help.View(m.ActiveKeys())
+	return s
+}
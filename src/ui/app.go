@@ -3,6 +3,8 @@ package ui
 import (
 	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -21,7 +23,7 @@ func (m *Model) Init() tea.Cmd {
 	if m.core.IsMonitoring() {
 		cmds = append(cmds, TickCmd())
 	}
-	cmds = append(cmds, m.spinner.Tick, textinput.Blink)
+	cmds = append(cmds, m.spinner.Tick, textinput.Blink, m.analysisCursor.Focus(), toastTickCmd())
 	return tea.Batch(cmds...)
 }
 
@@ -43,32 +45,44 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.settingsInput, cmd = m.settingsInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if m.currentView == "activity" && m.filterActive {
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch m.currentView {
-		case "main":
-			return m.updateMain(msg)
-		case "activity":
-			return m.updateActivity(msg)
-		case "activity_detail":
-			return m.updateActivityDetail(msg)
-		case "settings":
-			return m.updateSettings(msg)
-		case "setup":
-			return m.updateSetup(msg)
-		case "task":
-			return m.updateTask(msg)
-		case "stats":
-			return m.updateStats(msg)
+		// The '?' overlay sits above the whole nav stack: while it's open,
+		// any of these keys closes it and nothing else reaches the view
+		// underneath.
+		if m.showHelp {
+			switch msg.String() {
+			case "?", "esc", "b", "q", "ctrl+c":
+				m.showHelp = false
+			}
+			return m, nil
+		}
+		// A blocking window (confirm dialog, quick-task prompt) sits above
+		// the nav stack too: while one is open it gets every key and the
+		// view underneath doesn't see them.
+		if m.wm.Blocking() {
+			return m, m.wm.UpdateTop(msg)
 		}
+		if key.Matches(msg, keyQuickTask) {
+			m.wm.Push(newQuickTaskWindow(m))
+			m.needsRedraw = true
+			return m, textinput.Blink
+		}
+		_, viewCmd := m.viewStack.Top().Update(msg)
+		return m, viewCmd
 	case tea.MouseMsg:
 		if m.currentView == "activity" && msg.Type == tea.MouseLeft {
-			return m.handleTableClick(msg)
+			_, viewCmd := m.viewStack.Top().Update(msg)
+			return m, viewCmd
 		}
 	case tickMsg:
 		m.lastUpdate = time.Time(msg)
-		
+
 		// Only update if monitoring is active or we're in activity view
 		if m.core.IsMonitoring() || m.currentView == "activity" {
 			if m.currentView == "activity" {
@@ -76,38 +90,67 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.needsRedraw = true
 		}
-		
+
 		// Only continue ticking if monitoring is active
 		if m.core.IsMonitoring() {
 			cmds = append(cmds, TickCmd())
 		}
+	case editorFinishedMsg:
+		m.handleEditorFinished(msg)
+		m.needsRedraw = true
+	case msgAnalysisChunk:
+		if m.analysisWaiting {
+			m.analysisText = ""
+			m.analysisTokens = 0
+			m.analysisStart = time.Now()
+			m.analysisWaiting = false
+		}
+		m.analysisText += msg.delta
+		m.analysisTokens++
+		m.needsRedraw = true
+		cmds = append(cmds, waitForAnalysisEvent(m.analysisChan))
+	case msgAnalysisEnd:
+		m.analysisWaiting = true
+		m.needsRedraw = true
+		cmds = append(cmds, waitForAnalysisEvent(m.analysisChan))
+	case msgAnalysisError:
+		m.analysisErr = msg.err
+		m.analysisWaiting = true
+		m.needsRedraw = true
+		cmds = append(cmds, waitForAnalysisEvent(m.analysisChan))
+	case cursor.BlinkMsg:
+		m.analysisCursor, cmd = m.analysisCursor.Update(msg)
+		cmds = append(cmds, cmd)
+	case toastTickMsg:
+		m.needsRedraw = true
+		cmds = append(cmds, m.wm.Broadcast(msg), toastTickCmd())
 	case tea.Msg: // Handle spinner updates
 		m.spinner, cmd = m.spinner.Update(msg)
 		cmds = append(cmds, cmd)
 	}
-	
+
 	return m, tea.Batch(cmds...)
 }
 
-// View renders the current view based on the model state
+// View renders the help overlay if it's open; otherwise the view on top of
+// the navigation stack, with any open windowmanager windows (a confirm
+// dialog, the quick-task prompt, toasts) rendered below it.
 func (m *Model) View() string {
-	switch m.currentView {
-	case "main":
-		return m.viewMain()
-	case "activity":
-		return m.viewActivity()
-	case "activity_detail":
-		return m.viewActivityDetail()
-	case "settings":
-		return m.viewSettings()
-	case "setup":
-		return m.viewSetup()
-	case "task":
-		return m.viewTask()
-	case "stats":
-		return m.viewStats()
+	if m.showHelp {
+		return m.viewHelp()
+	}
+
+	var base string
+	if top := m.viewStack.Top(); top != nil {
+		base = top.View()
+	} else {
+		base = m.viewMain()
+	}
+
+	if m.wm.Active() {
+		return base + "\n\n" + m.wm.View()
 	}
-	return m.viewMain()
+	return base
 }
 
 // NewProgram creates a new tea.Program with the given model
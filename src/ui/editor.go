@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorTarget identifies which field a round trip through $EDITOR is
+// editing, so handleEditorFinished knows how to parse and apply the result.
+type editorTarget int
+
+const (
+	editorTargetNone editorTarget = iota
+	editorTargetTask
+	editorTargetProductiveApps
+	editorTargetDistractingApps
+)
+
+// editorFinishedMsg is returned by the tea.ExecProcess callback once the
+// external editor exits; app.go routes it to handleEditorFinished.
+type editorFinishedMsg struct {
+	target editorTarget
+	path   string
+	err    error
+}
+
+// openEditorCmd suspends the TUI and opens $EDITOR (falling back to "vi")
+// on a temp file pre-filled with initial, for fields too long to
+// comfortably edit in a single-line textinput (a task description, or a
+// newline-separated app list). The temp file is removed once
+// handleEditorFinished has read it back.
+func openEditorCmd(target editorTarget, initial string) tea.Cmd {
+	f, err := os.CreateTemp("", "snitch-*.txt")
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{target: target, err: err} }
+	}
+	path := f.Name()
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		os.Remove(path)
+		return func() tea.Msg { return editorFinishedMsg{target: target, err: err} }
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return editorFinishedMsg{target: target, err: err} }
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command(editor, path)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{target: target, path: path, err: err}
+	})
+}
+
+// handleEditorFinished reads back the temp file left by openEditorCmd,
+// applies it to the field named by msg.target, and reports the outcome
+// through that field's usual message/style (taskMessage or
+// settingsMessage).
+func (m *Model) handleEditorFinished(msg editorFinishedMsg) {
+	target := msg.target
+	m.editorTarget = editorTargetNone
+
+	if msg.path != "" {
+		defer os.Remove(msg.path)
+	}
+
+	if msg.err != nil {
+		m.reportEditorResult(target, "[ERROR] $EDITOR exited with an error: "+msg.err.Error())
+		return
+	}
+
+	content, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.reportEditorResult(target, "[ERROR] Could not read edited file: "+err.Error())
+		return
+	}
+
+	switch target {
+	case editorTargetTask:
+		task := strings.TrimSpace(string(content))
+		if task == "" {
+			m.reportEditorResult(target, "[ERROR] Task description cannot be empty")
+			return
+		}
+		m.taskInput.SetValue(task)
+		m.core.SetCurrentTask(task)
+		m.currentView = "main"
+		m.status = "[TASK] Current task: " + task
+		m.taskInput.Blur()
+	case editorTargetProductiveApps, editorTargetDistractingApps:
+		apps := parseEditorAppList(string(content))
+		cfg := m.core.GetConfig()
+		if target == editorTargetProductiveApps {
+			cfg.ProductiveApps = apps
+			m.reportEditorResult(target, "[SUCCESS] Productive apps updated")
+		} else {
+			cfg.DistractingApps = apps
+			m.reportEditorResult(target, "[SUCCESS] Distracting apps updated")
+		}
+		m.settingsInput.SetValue(strings.Join(apps, ", "))
+	}
+}
+
+// reportEditorResult surfaces an $EDITOR round-trip result through the
+// message field the originating view already renders, and as a toast.
+func (m *Model) reportEditorResult(target editorTarget, message string) {
+	switch target {
+	case editorTargetTask:
+		m.setTaskMessage(message)
+	case editorTargetProductiveApps, editorTargetDistractingApps:
+		m.setSettingsMessage(message)
+	}
+}
+
+// parseEditorAppList splits $EDITOR content for an app list into names,
+// accepting either one app per line or a comma-separated line (whichever
+// the user wrote), dropping blanks.
+func parseEditorAppList(content string) []string {
+	apps := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		for _, app := range strings.Split(line, ",") {
+			app = strings.TrimSpace(app)
+			if app != "" {
+				apps = append(apps, app)
+			}
+		}
+	}
+	return apps
+}
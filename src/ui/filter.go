@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+// activityStatusFilter cycles the activity log between all, productive-only
+// and distracted-only, independently of the free-text filter bar, via the
+// 's' hotkey.
+type activityStatusFilter int
+
+const (
+	activityStatusAll activityStatusFilter = iota
+	activityStatusProductive
+	activityStatusDistracted
+)
+
+// String renders the status filter for the activity view's filter line.
+func (f activityStatusFilter) String() string {
+	switch f {
+	case activityStatusProductive:
+		return "productive"
+	case activityStatusDistracted:
+		return "distracted"
+	default:
+		return "all"
+	}
+}
+
+// next cycles all -> productive -> distracted -> all.
+func (f activityStatusFilter) next() activityStatusFilter {
+	return (f + 1) % 3
+}
+
+// activityFilters holds the activity log's filter bar query (app:, score<,
+// status:, and free-text terms) compiled into a matcher, the status-cycle
+// hotkey state, and the shown/total counts from the last updateActivityTable
+// pass, so the activity view can report "N/M shown".
+type activityFilters struct {
+	query  string
+	status activityStatusFilter
+	match  func(core.Activity) bool
+	shown  int
+	total  int
+}
+
+// compile parses f.query into predicates and folds them, together with the
+// status cycle, into f.match. An empty query with status "all" matches
+// everything.
+func (f *activityFilters) compile() {
+	terms := strings.Fields(f.query)
+	predicates := make([]func(core.Activity) bool, 0, len(terms)+1)
+
+	for _, term := range terms {
+		predicates = append(predicates, parseFilterTerm(term))
+	}
+
+	switch f.status {
+	case activityStatusProductive:
+		predicates = append(predicates, func(a core.Activity) bool { return a.IsProductive })
+	case activityStatusDistracted:
+		predicates = append(predicates, func(a core.Activity) bool { return !a.IsProductive })
+	}
+
+	f.match = func(a core.Activity) bool {
+		for _, p := range predicates {
+			if !p(a) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// parseFilterTerm compiles one space-separated token of the filter bar's
+// query grammar: "app:Slack", "score<0.4", "score>0.8", "status:productive"/
+// "status:distracted", or a bare term matched as a case-insensitive
+// substring of Activity.
+func parseFilterTerm(term string) func(core.Activity) bool {
+	switch {
+	case strings.HasPrefix(term, "app:"):
+		want := strings.ToLower(strings.TrimPrefix(term, "app:"))
+		return func(a core.Activity) bool { return strings.Contains(strings.ToLower(a.Application), want) }
+	case strings.HasPrefix(term, "status:"):
+		productive := strings.TrimPrefix(term, "status:") == "productive"
+		return func(a core.Activity) bool { return a.IsProductive == productive }
+	case strings.HasPrefix(term, "score") && strings.ContainsAny(term, "<>"):
+		return parseScoreTerm(term)
+	default:
+		want := strings.ToLower(term)
+		return func(a core.Activity) bool { return strings.Contains(strings.ToLower(a.Activity), want) }
+	}
+}
+
+// parseScoreTerm compiles "score<0.4" or "score>0.8"; an unparseable
+// threshold matches everything rather than hiding the whole log.
+func parseScoreTerm(term string) func(core.Activity) bool {
+	idx := strings.IndexAny(term, "<>")
+	if idx < 0 {
+		return func(core.Activity) bool { return true }
+	}
+
+	op := term[idx]
+	threshold, err := strconv.ParseFloat(term[idx+1:], 64)
+	if err != nil {
+		return func(core.Activity) bool { return true }
+	}
+
+	if op == '>' {
+		return func(a core.Activity) bool { return a.ProductivityScore > threshold }
+	}
+	return func(a core.Activity) bool { return a.ProductivityScore < threshold }
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/varadanvk/snitch/src/core"
+)
+
+func TestParseFilterTermAppPrefix(t *testing.T) {
+	match := parseFilterTerm("app:Slack")
+
+	if !match(core.Activity{Application: "slack"}) {
+		t.Error("expected case-insensitive substring match on application")
+	}
+	if match(core.Activity{Application: "Chrome"}) {
+		t.Error("expected no match for unrelated application")
+	}
+}
+
+func TestParseFilterTermStatusPrefix(t *testing.T) {
+	productive := parseFilterTerm("status:productive")
+	if !productive(core.Activity{IsProductive: true}) {
+		t.Error("expected status:productive to match a productive activity")
+	}
+	if productive(core.Activity{IsProductive: false}) {
+		t.Error("expected status:productive to reject a distracting activity")
+	}
+
+	distracted := parseFilterTerm("status:distracted")
+	if !distracted(core.Activity{IsProductive: false}) {
+		t.Error("expected status:distracted to match a distracting activity")
+	}
+}
+
+func TestParseFilterTermScore(t *testing.T) {
+	under := parseFilterTerm("score<0.4")
+	if !under(core.Activity{ProductivityScore: 0.1}) {
+		t.Error("expected score<0.4 to match 0.1")
+	}
+	if under(core.Activity{ProductivityScore: 0.5}) {
+		t.Error("expected score<0.4 to reject 0.5")
+	}
+
+	over := parseFilterTerm("score>0.8")
+	if !over(core.Activity{ProductivityScore: 0.9}) {
+		t.Error("expected score>0.8 to match 0.9")
+	}
+	if over(core.Activity{ProductivityScore: 0.1}) {
+		t.Error("expected score>0.8 to reject 0.1")
+	}
+}
+
+func TestParseFilterTermScoreUnparseableThresholdMatchesEverything(t *testing.T) {
+	match := parseFilterTerm("score<nope")
+	if !match(core.Activity{ProductivityScore: 0}) {
+		t.Error("expected an unparseable threshold to match everything rather than hide the log")
+	}
+}
+
+func TestParseFilterTermBareTerm(t *testing.T) {
+	match := parseFilterTerm("Review")
+	if !match(core.Activity{Activity: "code review in progress"}) {
+		t.Error("expected case-insensitive substring match on activity description")
+	}
+	if match(core.Activity{Activity: "watching videos"}) {
+		t.Error("expected no match for unrelated activity")
+	}
+}
+
+func TestActivityFiltersCompileCombinesTermsWithStatus(t *testing.T) {
+	f := &activityFilters{query: "app:Slack score>0.5", status: activityStatusProductive}
+	f.compile()
+
+	matching := core.Activity{Application: "Slack", ProductivityScore: 0.9, IsProductive: true}
+	if !f.match(matching) {
+		t.Error("expected activity matching all predicates to match")
+	}
+
+	wrongApp := matching
+	wrongApp.Application = "Chrome"
+	if f.match(wrongApp) {
+		t.Error("expected app: predicate to exclude a different application")
+	}
+
+	notProductive := matching
+	notProductive.IsProductive = false
+	if f.match(notProductive) {
+		t.Error("expected status cycle predicate to exclude a non-productive activity")
+	}
+}
+
+func TestActivityFiltersCompileEmptyQueryMatchesEverything(t *testing.T) {
+	f := &activityFilters{}
+	f.compile()
+
+	if !f.match(core.Activity{}) {
+		t.Error("expected an empty query with status all to match everything")
+	}
+}
+
+func TestActivityStatusFilterNextCycles(t *testing.T) {
+	f := activityStatusAll
+	if f.next() != activityStatusProductive {
+		t.Error("expected all -> productive")
+	}
+	if f.next().next() != activityStatusDistracted {
+		t.Error("expected all -> productive -> distracted")
+	}
+	if f.next().next().next() != activityStatusAll {
+		t.Error("expected the cycle to wrap back to all")
+	}
+}
@@ -4,23 +4,29 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/varadanvk/snitch/src/core"
 )
 
 // updateMain handles main menu navigation and selection
 func (m *Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	oldCursor := m.cursor
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch {
+	case key.Matches(msg, keyQuit):
 		if m.core.IsMonitoring() {
 			m.core.StopMonitoring()
 		}
 		return m, tea.Quit
-	case "up", "k":
+	case key.Matches(msg, helpKey):
+		m.showHelp = true
+		m.needsRedraw = true
+	case key.Matches(msg, keyUp):
 		for {
 			if m.cursor > 0 {
 				m.cursor--
@@ -32,7 +38,7 @@ func (m *Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
-	case "down", "j":
+	case key.Matches(msg, keyDown):
 		for {
 			if m.cursor < len(m.choices)-1 {
 				m.cursor++
@@ -44,7 +50,7 @@ func (m *Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
-	case "enter", " ":
+	case key.Matches(msg, keySelect):
 		return m.handleMainSelection()
 	}
 
@@ -73,10 +79,14 @@ func (m *Model) handleMainSelection() (tea.Model, tea.Cmd) {
 		}
 	case 1: // Stop Monitoring
 		if m.core.IsMonitoring() {
-			m.core.StopMonitoring()
-			m.status = "[STOPPED] Monitoring stopped"
-			// Move cursor to start monitoring
-			m.cursor = 0
+			m.wm.Push(newConfirmWindow("Stop monitoring?", func() tea.Cmd {
+				m.core.StopMonitoring()
+				m.status = "[STOPPED] Monitoring stopped"
+				m.cursor = 0
+				m.needsRedraw = true
+				m.pushToast("[SUCCESS] Monitoring stopped")
+				return nil
+			}))
 			m.needsRedraw = true
 		}
 	case 2: // View Activity Log
@@ -87,15 +97,29 @@ func (m *Model) handleMainSelection() (tea.Model, tea.Cmd) {
 		m.currentView = "stats"
 		m.status = "Productivity Statistics - Press 'b' to go back"
 		m.needsRedraw = true
-	case 4: // Settings
+	case 4: // Timeline
+		m.currentView = "timeline"
+		m.status = "Timeline - Press 'b' to go back"
+		m.needsRedraw = true
+	case 5: // Clusters
+		m.currentView = "clusters"
+		m.status = "Activity Clusters - Press 'b' to go back"
+		m.needsRedraw = true
+	case 6: // Live Analysis
+		m.currentView = "analysis"
+		m.status = "Live AI Analysis - Press 'b' to go back"
+		m.resetAnalysisStream()
+		m.needsRedraw = true
+		cmds = append(cmds, waitForAnalysisEvent(m.analysisChan), m.spinner.Tick)
+	case 7: // Settings
 		m.currentView = "settings"
 		m.status = "Settings - Press 'b' to go back"
 		m.needsRedraw = true
-	case 5: // AI Setup
+	case 8: // AI Setup
 		m.currentView = "setup"
 		m.status = "AI Setup - Press 'b' to go back"
 		m.needsRedraw = true
-	case 6: // Set Current Task
+	case 9: // Set Current Task
 		m.currentView = "task"
 		m.taskInput.Focus()
 		m.taskInput.SetValue(m.core.GetCurrentTask()) // Pre-fill with current task
@@ -103,7 +127,12 @@ func (m *Model) handleMainSelection() (tea.Model, tea.Cmd) {
 		m.status = "Set Current Task"
 		m.needsRedraw = true
 		cmds = append(cmds, textinput.Blink)
-	case 7: // Quit
+	case 10: // Session History
+		m.currentView = "sessions"
+		m.status = "Session History - Press 'b' to go back"
+		m.updateSessionsTable()
+		m.needsRedraw = true
+	case 11: // Quit
 		if m.core.IsMonitoring() {
 			m.core.StopMonitoring()
 		}
@@ -114,15 +143,47 @@ func (m *Model) handleMainSelection() (tea.Model, tea.Cmd) {
 
 // updateActivity handles activity view navigation
 func (m *Model) updateActivity(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While the filter bar is focused, keystrokes edit the query (applied to
+	// m.filterInput by app.go's Update before this runs) rather than moving
+	// the table cursor. Re-derive the matcher and re-filter on every
+	// keystroke so the "N/M shown" line and rows update live.
+	if m.filterActive {
+		switch {
+		case key.Matches(msg, keyEsc), key.Matches(msg, keyEnter):
+			m.filterActive = false
+			m.filterInput.Blur()
+		case key.Matches(msg, keyForceQuit):
+			return m, tea.Quit
+		}
+		m.filters.query = m.filterInput.Value()
+		m.filters.compile()
+		m.updateActivityTable()
+		m.needsRedraw = true
+		return m, nil
+	}
+
 	var cmd tea.Cmd
-	switch msg.String() {
-	case "esc", "b":
+	switch {
+	case key.Matches(msg, keyBack):
 		m.currentView = "main"
 		m.status = "Snitch AI Productivity Monitor - Ready"
 		m.needsRedraw = true
-	case "ctrl+c", "q":
+	case key.Matches(msg, keyQuit):
 		return m, tea.Quit
-	case "enter":
+	case key.Matches(msg, helpKey):
+		m.showHelp = true
+		m.needsRedraw = true
+	case key.Matches(msg, keyFilter):
+		m.filterActive = true
+		m.filterInput.Focus()
+		m.needsRedraw = true
+		cmd = textinput.Blink
+	case key.Matches(msg, keyStatus):
+		m.filters.status = m.filters.status.next()
+		m.filters.compile()
+		m.updateActivityTable()
+		m.needsRedraw = true
+	case key.Matches(msg, keyEnter):
 		// Show detail view for selected row
 		selectedRow := m.activityTable.Cursor()
 		if selectedRow < len(m.activities) {
@@ -164,13 +225,27 @@ func (m *Model) handleTableClick(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// updateActivityTable updates the activity table with recent data
+// updateActivityTable updates the activity table with recent data, narrowed
+// by m.filters' compiled matcher before building rows.
 func (m *Model) updateActivityTable() {
 	recent := m.core.GetRecentActivities(20)
-	m.activities = recent // Store for detail view
+	if m.filters.match == nil {
+		m.filters.compile()
+	}
+
+	filtered := make([]core.Activity, 0, len(recent))
+	for _, activity := range recent {
+		if m.filters.match(activity) {
+			filtered = append(filtered, activity)
+		}
+	}
+	m.filters.total = len(recent)
+	m.filters.shown = len(filtered)
+
+	m.activities = filtered // Store for detail view
 	rows := []table.Row{}
 
-	for i, activity := range recent {
+	for i, activity := range filtered {
 		status := "[DISTRACTED]"
 		if activity.IsProductive {
 			status = "[PRODUCTIVE]"
@@ -197,37 +272,150 @@ func (m *Model) updateActivityTable() {
 	m.activityTable.SetRows(rows)
 }
 
+// updateSessions handles the session history list's navigation and
+// drill-down into a session's activities.
+func (m *Model) updateSessions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch {
+	case key.Matches(msg, keyBack):
+		m.currentView = "main"
+		m.status = "Snitch AI Productivity Monitor - Ready"
+		m.needsRedraw = true
+	case key.Matches(msg, keyQuit):
+		return m, tea.Quit
+	case key.Matches(msg, helpKey):
+		m.showHelp = true
+		m.needsRedraw = true
+	case key.Matches(msg, keyEnter):
+		selectedRow := m.sessionTable.Cursor()
+		if selectedRow < len(m.sessions) {
+			m.selectedSession = &m.sessions[selectedRow]
+			m.currentView = "session_detail"
+			m.status = "Session Details - Press 'b' to go back"
+			m.updateSessionActivityTable()
+			m.needsRedraw = true
+		}
+	default:
+		m.sessionTable, cmd = m.sessionTable.Update(msg)
+	}
+	return m, cmd
+}
+
+// updateSessionDetail handles the session detail view's navigation.
+func (m *Model) updateSessionDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch {
+	case key.Matches(msg, keyBack):
+		m.currentView = "sessions"
+		m.status = "Session History - Press 'b' to go back"
+		m.selectedSession = nil
+	case key.Matches(msg, keyQuit):
+		return m, tea.Quit
+	case key.Matches(msg, helpKey):
+		m.showHelp = true
+	default:
+		m.activityTable, cmd = m.activityTable.Update(msg)
+	}
+	return m, cmd
+}
+
+// updateSessionsTable refreshes m.sessions from the core and rebuilds
+// sessionTable's rows.
+func (m *Model) updateSessionsTable() {
+	m.sessions = m.core.GetSessions(50)
+
+	rows := []table.Row{}
+	for _, session := range m.sessions {
+		productive := fmt.Sprintf("%.1f%%", session.ProductivityRate*100)
+		task := session.Task
+		if task == "" {
+			task = "-"
+		}
+		rows = append(rows, table.Row{
+			session.Start.Format("Jan 2 15:04"),
+			session.Duration().Round(time.Second).String(),
+			productive,
+			task,
+			strings.Join(session.TopApps, ", "),
+		})
+	}
+	m.sessionTable.SetRows(rows)
+}
+
+// updateSessionActivityTable repopulates the shared activityTable (reused
+// from the activity log view) with m.selectedSession's own activities, so
+// "drilling into" a session doesn't need a second table widget.
+func (m *Model) updateSessionActivityTable() {
+	if m.selectedSession == nil {
+		return
+	}
+
+	activities := m.core.GetActivitiesInRange(m.selectedSession.Start, m.selectedSession.End)
+	m.activities = activities
+
+	rows := []table.Row{}
+	for i, activity := range activities {
+		status := "[DISTRACTED]"
+		if activity.IsProductive {
+			status = "[PRODUCTIVE]"
+		}
+		score := fmt.Sprintf("%.1f", activity.ProductivityScore*100)
+		activityDesc := activity.Activity
+		if len(activityDesc) > 25 {
+			activityDesc = activityDesc[:22] + "..."
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", i),
+			activity.Timestamp.Format("15:04:05"),
+			status,
+			activityDesc,
+			activity.Application,
+			score + "%",
+		})
+	}
+	m.activityTable.SetRows(rows)
+}
+
 // updateSettings handles settings view navigation and editing
 func (m *Model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	if m.settingsEditing {
-		switch msg.String() {
-		case "esc":
+		switch {
+		case key.Matches(msg, keyEsc):
 			m.settingsEditing = false
 			m.settingsInput.Blur()
 			m.settingsMessage = ""
-		case "enter":
+		case key.Matches(msg, keyEditor) && (m.settingsCursor == 4 || m.settingsCursor == 5):
+			target := editorTargetProductiveApps
+			if m.settingsCursor == 5 {
+				target = editorTargetDistractingApps
+			}
+			m.editorTarget = target
+			return m, openEditorCmd(target, strings.ReplaceAll(m.settingsInput.Value(), ", ", "\n"))
+		case key.Matches(msg, keyEnter):
 			return m.handleSettingsSave()
-		case "ctrl+c", "q":
+		case key.Matches(msg, keyQuit):
 			return m, tea.Quit
 		}
 	} else {
-		switch msg.String() {
-		case "esc", "b":
+		switch {
+		case key.Matches(msg, keyBack):
 			m.currentView = "main"
 			m.status = "Snitch AI Productivity Monitor - Ready"
-		case "ctrl+c", "q":
+		case key.Matches(msg, keyQuit):
 			return m, tea.Quit
-		case "up", "k":
+		case key.Matches(msg, helpKey):
+			m.showHelp = true
+		case key.Matches(msg, keyUp):
 			if m.settingsCursor > 0 {
 				m.settingsCursor--
 			}
-		case "down", "j":
+		case key.Matches(msg, keyDown):
 			if m.settingsCursor < len(m.settingsItems)-1 {
 				m.settingsCursor++
 			}
-		case "enter", " ":
+		case key.Matches(msg, keySelect):
 			return m.handleSettingsEdit()
 		}
 	}
@@ -252,6 +440,12 @@ func (m *Model) handleSettingsEdit() (tea.Model, tea.Cmd) {
 		currentValue = strings.Join(cfg.ProductiveApps, ", ")
 	case 5: // Distracting Apps
 		currentValue = strings.Join(cfg.DistractingApps, ", ")
+	case 6: // CalDAV URL
+		currentValue = cfg.CalDAVURL
+	case 7: // CalDAV Username
+		currentValue = cfg.CalDAVUsername
+	case 8: // CalDAV Password
+		currentValue = cfg.CalDAVPassword
 	}
 
 	m.settingsEditing = true
@@ -268,7 +462,7 @@ func (m *Model) handleSettingsSave() (tea.Model, tea.Cmd) {
 	newValue := strings.TrimSpace(m.settingsInput.Value())
 
 	if newValue == "" {
-		m.settingsMessage = "[ERROR] Value cannot be empty"
+		m.setSettingsMessage("[ERROR] Value cannot be empty")
 		return m, nil
 	}
 
@@ -276,33 +470,33 @@ func (m *Model) handleSettingsSave() (tea.Model, tea.Cmd) {
 	case 0: // Monitoring Interval
 		if val, err := strconv.Atoi(newValue); err == nil && val > 0 {
 			cfg.MonitoringInterval = val
-			m.settingsMessage = "[SUCCESS] Monitoring interval updated"
+			m.setSettingsMessage("[SUCCESS] Monitoring interval updated")
 		} else {
-			m.settingsMessage = "[ERROR] Invalid number (must be > 0)"
+			m.setSettingsMessage("[ERROR] Invalid number (must be > 0)")
 			return m, nil
 		}
 	case 1: // Notification Interval
 		if val, err := strconv.Atoi(newValue); err == nil && val > 0 {
 			cfg.NotificationInterval = val
-			m.settingsMessage = "[SUCCESS] Notification interval updated"
+			m.setSettingsMessage("[SUCCESS] Notification interval updated")
 		} else {
-			m.settingsMessage = "[ERROR] Invalid number (must be > 0)"
+			m.setSettingsMessage("[ERROR] Invalid number (must be > 0)")
 			return m, nil
 		}
 	case 2: // Save Screenshots
 		if val, err := strconv.ParseBool(newValue); err == nil {
 			cfg.SaveScreenshots = val
-			m.settingsMessage = "[SUCCESS] Save screenshots updated"
+			m.setSettingsMessage("[SUCCESS] Save screenshots updated")
 		} else {
-			m.settingsMessage = "[ERROR] Invalid boolean (true/false)"
+			m.setSettingsMessage("[ERROR] Invalid boolean (true/false)")
 			return m, nil
 		}
 	case 3: // Snitch Mode
 		if val, err := strconv.ParseBool(newValue); err == nil {
 			cfg.SnitchMode = val
-			m.settingsMessage = "[SUCCESS] Snitch mode updated"
+			m.setSettingsMessage("[SUCCESS] Snitch mode updated")
 		} else {
-			m.settingsMessage = "[ERROR] Invalid boolean (true/false)"
+			m.setSettingsMessage("[ERROR] Invalid boolean (true/false)")
 			return m, nil
 		}
 	case 4: // Productive Apps
@@ -314,7 +508,7 @@ func (m *Model) handleSettingsSave() (tea.Model, tea.Cmd) {
 			}
 		}
 		cfg.ProductiveApps = apps
-		m.settingsMessage = "[SUCCESS] Productive apps updated"
+		m.setSettingsMessage("[SUCCESS] Productive apps updated")
 	case 5: // Distracting Apps
 		apps := []string{}
 		for _, app := range strings.Split(newValue, ",") {
@@ -324,7 +518,16 @@ func (m *Model) handleSettingsSave() (tea.Model, tea.Cmd) {
 			}
 		}
 		cfg.DistractingApps = apps
-		m.settingsMessage = "[SUCCESS] Distracting apps updated"
+		m.setSettingsMessage("[SUCCESS] Distracting apps updated")
+	case 6: // CalDAV URL
+		cfg.CalDAVURL = newValue
+		m.setSettingsMessage("[SUCCESS] CalDAV URL updated")
+	case 7: // CalDAV Username
+		cfg.CalDAVUsername = newValue
+		m.setSettingsMessage("[SUCCESS] CalDAV username updated")
+	case 8: // CalDAV Password
+		cfg.CalDAVPassword = newValue
+		m.setSettingsMessage("[SUCCESS] CalDAV password updated")
 	}
 
 	// Save configuration - This needs to be implemented by the concrete core type
@@ -342,65 +545,95 @@ func (m *Model) updateSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch m.setupStep {
 	case 0: // Choose backend
-		switch msg.String() {
-		case "esc", "b":
+		switch {
+		case key.Matches(msg, keyBack):
 			m.currentView = "main"
 			m.status = "Snitch AI Productivity Monitor - Ready"
 			m.setupStep = 0
-		case "ctrl+c", "q":
+		case key.Matches(msg, keyQuit):
 			return m, tea.Quit
-		case "1":
+		case key.Matches(msg, helpKey):
+			m.showHelp = true
+		case msg.String() == "1":
 			// Choose Groq
+			m.setupBackend = "groq"
 			m.setupStep = 1
 			m.textInput.Focus()
 			m.textInput.SetValue("")
 			m.setupMessage = ""
 			m.status = "Enter your Groq API key"
 			cmd = textinput.Blink
-		case "2":
+		case msg.String() == "2":
 			// Choose Ollama
 			cfg := m.core.GetConfig()
 			cfg.AIBackend = "ollama"
 			// Save configuration - handled by core
-			m.setupMessage = "[SUCCESS] Ollama backend selected! Make sure Ollama is running with 'ollama pull llava'"
+			m.setSetupMessage("[SUCCESS] Ollama backend selected! Make sure Ollama is running with 'ollama pull llava'")
 			m.setupStep = 2
+		case msg.String() == "3":
+			// Choose Anthropic
+			m.setupBackend = "anthropic"
+			m.setupStep = 1
+			m.textInput.Focus()
+			m.textInput.SetValue("")
+			m.setupMessage = ""
+			m.status = "Enter your Anthropic API key"
+			cmd = textinput.Blink
+		case msg.String() == "4":
+			// Choose OpenAI
+			m.setupBackend = "openai"
+			m.setupStep = 1
+			m.textInput.Focus()
+			m.textInput.SetValue("")
+			m.setupMessage = ""
+			m.status = "Enter your OpenAI API key"
+			cmd = textinput.Blink
 		}
-	case 1: // Enter Groq API key
-		switch msg.String() {
-		case "esc":
+	case 1: // Enter API key for m.setupBackend
+		switch {
+		case key.Matches(msg, keyEsc):
 			m.setupStep = 0
 			m.textInput.Blur()
 			m.setupMessage = ""
 			m.status = "AI Setup - Choose your backend"
-		case "ctrl+c", "q":
+		case key.Matches(msg, keyQuit):
 			return m, tea.Quit
-		case "enter":
+		case key.Matches(msg, keyEnter):
 			apiKey := strings.TrimSpace(m.textInput.Value())
 			if len(apiKey) > 10 { // Basic validation
-				// Save the API key
 				cfg := m.core.GetConfig()
-				cfg.GroqAPIKey = apiKey
-				cfg.AIBackend = "groq"
+				label, _ := setupKeyLabel(m.setupBackend)
+				switch m.setupBackend {
+				case "anthropic":
+					cfg.AnthropicAPIKey = apiKey
+				case "openai":
+					cfg.OpenAIAPIKey = apiKey
+				default:
+					cfg.GroqAPIKey = apiKey
+				}
+				cfg.AIBackend = m.setupBackend
 				// Save configuration - handled by core
 
 				// Recreate analyzer - would be handled by core implementation
 
-				m.setupMessage = "[SUCCESS] Groq API key saved! AI analysis is now enabled."
+				m.setSetupMessage("[SUCCESS] " + label + " API key saved! AI analysis is now enabled.")
 				m.setupStep = 2
 				m.textInput.Blur()
 			} else {
-				m.setupMessage = "[ERROR] Please enter a valid API key (should be longer than 10 characters)"
+				m.setSetupMessage("[ERROR] Please enter a valid API key (should be longer than 10 characters)")
 			}
 		}
 	case 2: // Confirmation
-		switch msg.String() {
-		case "esc", "b", "enter":
+		switch {
+		case key.Matches(msg, keyBack), key.Matches(msg, keyEnter):
 			m.currentView = "main"
 			m.status = "Snitch AI Productivity Monitor - Ready"
 			m.setupStep = 0
 			m.setupMessage = ""
-		case "ctrl+c", "q":
+		case key.Matches(msg, keyQuit):
 			return m, tea.Quit
+		case key.Matches(msg, helpKey):
+			m.showHelp = true
 		}
 	}
 	return m, cmd
@@ -410,29 +643,32 @@ func (m *Model) updateSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) updateTask(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	switch msg.String() {
-	case "esc", "b":
+	switch {
+	case key.Matches(msg, keyBack):
 		m.currentView = "main"
 		m.status = "Snitch AI Productivity Monitor - Ready"
 		m.taskInput.Blur()
 		m.taskMessage = ""
-	case "ctrl+c", "q":
+	case key.Matches(msg, keyQuit):
 		return m, tea.Quit
-	case "enter":
+	case key.Matches(msg, keyEditor):
+		m.editorTarget = editorTargetTask
+		return m, openEditorCmd(editorTargetTask, m.taskInput.Value())
+	case key.Matches(msg, keyEnter):
 		task := strings.TrimSpace(m.taskInput.Value())
 		if len(task) > 0 {
 			// Set the current task
 			m.core.SetCurrentTask(task)
-			m.taskMessage = "[SUCCESS] Current task set successfully!"
+			m.setTaskMessage("[SUCCESS] Current task set successfully!")
 
 			// Auto-return to main after 2 seconds or on next key press
 			m.currentView = "main"
 			m.status = fmt.Sprintf("[TASK] Current task: %s", task)
 			m.taskInput.Blur()
 		} else {
-			m.taskMessage = "[ERROR] Please enter a task description"
+			m.setTaskMessage("[ERROR] Please enter a task description")
 		}
-	case "tab":
+	case msg.String() == "tab":
 		// Focus the input if not already focused
 		if !m.taskInput.Focused() {
 			m.taskInput.Focus()
@@ -445,6 +681,20 @@ func (m *Model) updateTask(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateStats handles stats view navigation
 func (m *Model) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keyBack):
+		m.currentView = "main"
+		m.status = "Snitch AI Productivity Monitor - Ready"
+	case key.Matches(msg, keyQuit):
+		return m, tea.Quit
+	case key.Matches(msg, helpKey):
+		m.showHelp = true
+	}
+	return m, nil
+}
+
+// updateClusters handles clusters view navigation.
+func (m *Model) updateClusters(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc", "b":
 		m.currentView = "main"
@@ -453,4 +703,28 @@ func (m *Model) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 	return m, nil
+}
+
+// updateTimeline handles timeline view navigation and filter cycling.
+func (m *Model) updateTimeline(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "b":
+		m.currentView = "main"
+		m.status = "Snitch AI Productivity Monitor - Ready"
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "g": // cycle bucket granularity (hour/day/week)
+		m.timelineBucketIdx = (m.timelineBucketIdx + 1) % len(timelineBuckets)
+		m.needsRedraw = true
+	case "a": // cycle application filter
+		m.timelineAppIdx = (m.timelineAppIdx + 1) % (len(m.timelineApps()) + 1)
+		m.needsRedraw = true
+	case "c": // cycle category filter
+		m.timelineCatIdx = (m.timelineCatIdx + 1) % (len(m.timelineCategories()) + 1)
+		m.needsRedraw = true
+	case "t": // cycle minimum productivity threshold
+		m.timelineMinScoreIdx = (m.timelineMinScoreIdx + 1) % len(timelineMinScores)
+		m.needsRedraw = true
+	}
+	return m, nil
 }
\ No newline at end of file
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap is a named, ordered set of key bindings for one view. It satisfies
+// help.KeyMap so it can be handed directly to a help.Model footer or the
+// full-screen '?' overlay: rows[0] is the single-line ShortHelp, the whole
+// grid is FullHelp. This is the bob TUI keymap pattern - bindings become
+// data the renderer walks instead of string literals scattered across
+// handlers.go/views.go.
+type KeyMap struct {
+	rows [][]key.Binding
+}
+
+// ShortHelp implements help.KeyMap.
+func (k KeyMap) ShortHelp() []key.Binding {
+	if len(k.rows) == 0 {
+		return nil
+	}
+	return k.rows[0]
+}
+
+// FullHelp implements help.KeyMap.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return k.rows
+}
+
+// Bindings shared across several views.
+var (
+	keyUp     = key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up"))
+	keyDown   = key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down"))
+	keySelect = key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "select"))
+	keyEnter  = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm"))
+	keyBack   = key.NewBinding(key.WithKeys("esc", "b"), key.WithHelp("b", "back"))
+	keyQuit   = key.NewBinding(key.WithKeys("ctrl+c", "q"), key.WithHelp("q", "quit"))
+
+	// helpKey flips the current view's full-screen help overlay. Views that
+	// take free-text input (task, setup's API key step, the activity filter
+	// bar) don't bind it, so '?' stays typeable there.
+	helpKey = key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help"))
+
+	// keyEditor suspends the TUI and opens $EDITOR on the current field, for
+	// values too long to comfortably fit a single-line textinput. See
+	// editor.go.
+	keyEditor = key.NewBinding(key.WithKeys("ctrl+e"), key.WithHelp("ctrl+e", "open in $EDITOR"))
+
+	// keyQuickTask opens the floating "Set Task" window (windows.go) from
+	// any screen. Chosen over a bare letter so it still reaches every
+	// text-input view (task, setup's API key step, the filter bar).
+	keyQuickTask = key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "set task"))
+)
+
+// mainKeys covers updateMain/viewMain.
+var mainKeys = KeyMap{rows: [][]key.Binding{
+	{keyUp, keyDown, keySelect},
+	{keyQuit, helpKey},
+}}
+
+// Activity-log-only bindings, added by chunk3-1's filter bar.
+var (
+	keyFilter = key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter"))
+	keyStatus = key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "cycle status"))
+)
+
+// activityKeys covers updateActivity/viewActivity outside the filter bar.
+var activityKeys = KeyMap{rows: [][]key.Binding{
+	{keyUp, keyDown, keySelect},
+	{keyFilter, keyStatus, keyBack, keyQuit, helpKey},
+}}
+
+// keyEsc is esc alone (no 'b' alias), for submodes where 'b' should still
+// reach free-text input rather than act as "back".
+var keyEsc = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))
+
+// keyForceQuit is ctrl+c alone (no 'q' alias), for the filter bar, where
+// 'q' is part of the query text.
+var keyForceQuit = key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit"))
+
+// activityFilterKeys covers updateActivity while the filter bar ('/') is
+// focused; only esc/enter/ctrl+c are bound so the rest of the keyboard
+// reaches the query text.
+var activityFilterKeys = KeyMap{rows: [][]key.Binding{
+	{keyEnter},
+	{keyEsc, keyForceQuit},
+}}
+
+// settingsKeys covers updateSettings/viewSettings when not editing a value.
+var settingsKeys = KeyMap{rows: [][]key.Binding{
+	{keyUp, keyDown, keySelect},
+	{keyBack, keyQuit, helpKey},
+}}
+
+// settingsEditKeys covers updateSettings while a value is being edited.
+var settingsEditKeys = KeyMap{rows: [][]key.Binding{
+	{keyEnter},
+	{keyEsc, keyQuit},
+}}
+
+// settingsEditAppsKeys covers updateSettings while editing Productive Apps
+// or Distracting Apps, which also bind ctrl+e to open the list in $EDITOR.
+var settingsEditAppsKeys = KeyMap{rows: [][]key.Binding{
+	{keyEnter, keyEditor},
+	{keyEsc, keyQuit},
+}}
+
+// setupChooseKeys covers updateSetup/viewSetup step 0 (choose backend).
+var setupChooseKeys = KeyMap{rows: [][]key.Binding{
+	{key.NewBinding(key.WithKeys("1", "2", "3", "4"), key.WithHelp("1-4", "choose backend"))},
+	{keyBack, keyQuit, helpKey},
+}}
+
+// setupKeyEntryKeys covers updateSetup/viewSetup step 1 (enter API key).
+var setupKeyEntryKeys = KeyMap{rows: [][]key.Binding{
+	{keyEnter},
+	{keyEsc, keyQuit},
+}}
+
+// setupConfirmKeys covers updateSetup/viewSetup step 2 (confirmation).
+var setupConfirmKeys = KeyMap{rows: [][]key.Binding{
+	{keyEnter},
+	{keyBack, keyQuit, helpKey},
+}}
+
+// taskKeys covers updateTask/viewTask. '?' is left unbound since the view
+// is a free-text task description input.
+var taskKeys = KeyMap{rows: [][]key.Binding{
+	{keyEnter, keyEditor},
+	{key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "focus input")), keyBack, keyQuit},
+}}
+
+// statsKeys covers updateStats/viewStats.
+var statsKeys = KeyMap{rows: [][]key.Binding{
+	{keyBack},
+	{keyQuit, helpKey},
+}}
+
+// analysisKeys covers updateAnalysis/viewAnalysis.
+var analysisKeys = KeyMap{rows: [][]key.Binding{
+	{keyBack},
+	{keyQuit, helpKey},
+}}
+
+// sessionsKeys covers updateSessions/viewSessions.
+var sessionsKeys = KeyMap{rows: [][]key.Binding{
+	{keyUp, keyDown, keySelect},
+	{keyBack, keyQuit, helpKey},
+}}
+
+// sessionDetailKeys covers updateSessionDetail/viewSessionDetail.
+var sessionDetailKeys = KeyMap{rows: [][]key.Binding{
+	{keyUp, keyDown},
+	{keyBack, keyQuit, helpKey},
+}}
@@ -3,6 +3,8 @@ package ui
 import (
 	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
@@ -10,6 +12,9 @@ import (
 
 	"github.com/varadanvk/snitch/src/config"
 	"github.com/varadanvk/snitch/src/core"
+	"github.com/varadanvk/snitch/src/core/cluster"
+	"github.com/varadanvk/snitch/src/ml"
+	"github.com/varadanvk/snitch/src/ui/windowmanager"
 )
 
 // SnitchCore interface defines the core functionality needed by the UI
@@ -22,6 +27,12 @@ type SnitchCore interface {
 	SetCurrentTask(task string)
 	GetCurrentTask() string
 	GetConfig() *config.Config
+	GetTokenStats() (ml.TokenStats, bool)
+	QueryTimeline(start, end time.Time, bucket time.Duration, filter core.Filter) (core.Timeline, error)
+	GetClusters() []cluster.Cluster
+	SubscribeAnalysis() (<-chan ml.AnalysisEvent, func())
+	GetSessions(limit int) []core.Session
+	GetActivitiesInRange(start, end time.Time) []core.Activity
 }
 
 // Model represents the main TUI model with all state and components
@@ -31,7 +42,20 @@ type Model struct {
 	cursor      int
 	status      string
 	lastUpdate  time.Time
-	currentView string // "main", "activity", "settings", "stats", "activity_detail", "setup", "task"
+	currentView string // "main", "activity", "settings", "stats", "activity_detail", "setup", "task", "timeline", "clusters", "analysis", "sessions", "session_detail"
+
+	// viewStack mirrors currentView as a navigation stack: each screen's
+	// existing updateX method still sets currentView on Esc/selection, and
+	// legacyView.Update turns that into the matching Push/Pop/Replace. See
+	// viewstack.go.
+	viewStack *ViewStack
+
+	// wm holds transient overlay windows (windowmanager.go/windows.go): a
+	// confirm dialog on Stop Monitoring, the quick "Set Task" prompt opened
+	// with keyQuickTask from any screen, and toasts surfacing the messages
+	// that used to live only in taskMessage/settingsMessage/setupMessage
+	// until the next render.
+	wm *windowmanager.WM
 
 	// Bubble components
 	activityTable table.Model
@@ -41,14 +65,61 @@ type Model struct {
 
 	// Activity detail view - now handled by ActivityComponent
 
+	// Activity log filter state
+	filters      activityFilters
+	filterInput  textinput.Model
+	filterActive bool // filter bar ('/') is focused for editing
+
+	// Keybinding registry (keys.go): viewKeys holds each view's static
+	// KeyMap; ActiveKeys() adds sub-state (editing, filtering, wizard step)
+	// on top for help's footer and the '?' full-screen overlay.
+	viewKeys map[string]KeyMap
+	help     help.Model
+	showHelp bool
+
 	// Setup state
-	setupStep    int // 0: choose backend, 1: enter groq key, 2: confirm
+	setupStep    int    // 0: choose backend, 1: enter API key, 2: confirm
+	setupBackend string // backend name ("groq", "anthropic", "openai") the step-1 key entry is for
 	setupMessage string
 
 	// Task state
 	taskInput   textinput.Model
 	taskMessage string
 
+	// editorTarget is set while an external $EDITOR round trip (editor.go)
+	// is in flight, naming the field handleEditorFinished should apply the
+	// edited content to.
+	editorTarget editorTarget
+
+	// Live AI analysis stream (analysis.go): analysisChan is subscribed
+	// when the "analysis" view is entered, analysisText accumulates
+	// streamed deltas for the in-flight cycle, and analysisCursor renders a
+	// blinking cursor at the end of that text while still waiting.
+	// analysisUnsubscribe drops analysisChan from core's subscriber list
+	// and must be called whenever the view is left or re-subscribed.
+	analysisChan        <-chan ml.AnalysisEvent
+	analysisUnsubscribe func()
+	analysisCursor      cursor.Model
+	analysisText        string
+	analysisTokens      int
+	analysisStart       time.Time
+	analysisWaiting     bool
+	analysisErr         error
+
+	// Session history state (handlers.go/views.go): sessionTable lists past
+	// monitoring sessions from core.GetSessions; selecting a row drills
+	// into session_detail, which repopulates activityTable (reused from
+	// the activity log view) with that session's own activities.
+	sessionTable    table.Model
+	sessions        []core.Session
+	selectedSession *core.Session
+
+	// Timeline state
+	timelineBucketIdx   int // index into timelineBuckets
+	timelineAppIdx      int // 0 = all applications, otherwise index into timelineApps()+1
+	timelineCatIdx      int // 0 = all categories, otherwise index into timelineCategories()+1
+	timelineMinScoreIdx int // index into timelineMinScores
+
 	// Settings state
 	settingsCursor  int
 	settingsEditing bool
@@ -113,6 +184,21 @@ func NewModel(core SnitchCore) *Model {
 		Bold(false)
 	t.SetStyles(s)
 
+	// Initialize table for session history
+	sessionColumns := []table.Column{
+		{Title: "Start", Width: 16},
+		{Title: "Duration", Width: 10},
+		{Title: "Productive", Width: 11},
+		{Title: "Task", Width: 30},
+		{Title: "Top Apps", Width: 24},
+	}
+	sessionTable := table.New(
+		table.WithColumns(sessionColumns),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	sessionTable.SetStyles(s)
+
 	// Initialize spinner
 	sp := spinner.New()
 	sp.Spinner = spinner.Line
@@ -139,6 +225,15 @@ func NewModel(core SnitchCore) *Model {
 	settingsInput.CharLimit = 100
 	settingsInput.Width = 40
 
+	// Initialize text input for the activity log filter bar
+	filterInput := textinput.New()
+	filterInput.Placeholder = "app:Slack score<0.4 status:distracted ..."
+	filterInput.CharLimit = 100
+	filterInput.Width = 50
+
+	// Initialize the blinking cursor for the live analysis view
+	analysisCursor := cursor.New()
+
 	m := &Model{
 		core: core,
 		choices: []string{
@@ -146,9 +241,13 @@ func NewModel(core SnitchCore) *Model {
 			"Stop Monitoring",
 			"View Activity Log",
 			"Productivity Stats",
+			"Timeline",
+			"Clusters",
+			"Live Analysis",
 			"Settings",
 			"AI Setup",
 			"Set Current Task",
+			"Session History",
 			"Quit",
 		},
 		status:        "Snitch AI Productivity Monitor - Ready",
@@ -168,15 +267,38 @@ func NewModel(core SnitchCore) *Model {
 			"Snitch Mode",
 			"Productive Apps",
 			"Distracting Apps",
+			"CalDAV URL",
+			"CalDAV Username",
+			"CalDAV Password",
+		},
+		filterInput:    filterInput,
+		analysisCursor: analysisCursor,
+		sessionTable:   sessionTable,
+		viewKeys: map[string]KeyMap{
+			"main":           mainKeys,
+			"activity":       activityKeys,
+			"settings":       settingsKeys,
+			"setup":          setupChooseKeys,
+			"task":           taskKeys,
+			"stats":          statsKeys,
+			"analysis":       analysisKeys,
+			"sessions":       sessionsKeys,
+			"session_detail": sessionDetailKeys,
 		},
+		help:           help.New(),
 		needsRedraw:    true,
 		lastRenderTime: time.Now(),
 		sessionStart:   time.Now(),
 	}
 
+	m.filters.compile()
+
 	// Ensure cursor starts on a valid position
 	m.cursor = m.findValidCursor(0, 1)
 
+	m.viewStack = NewViewStack(newLegacyView(m, "main"))
+	m.wm = windowmanager.New()
+
 	return m
 }
 
@@ -208,6 +330,34 @@ func (m *Model) findValidCursor(start, direction int) int {
 	return start // fallback
 }
 
+// ActiveKeys returns the KeyMap for whatever the current view is actually
+// showing right now, layering sub-state (a wizard step, an editing mode, the
+// activity filter bar) on top of m.viewKeys' static per-view entries. The
+// help footer and the '?' overlay both render whatever this returns.
+func (m *Model) ActiveKeys() KeyMap {
+	switch m.currentView {
+	case "activity":
+		if m.filterActive {
+			return activityFilterKeys
+		}
+	case "settings":
+		if m.settingsEditing {
+			if m.settingsCursor == 4 || m.settingsCursor == 5 {
+				return settingsEditAppsKeys
+			}
+			return settingsEditKeys
+		}
+	case "setup":
+		switch m.setupStep {
+		case 1:
+			return setupKeyEntryKeys
+		case 2:
+			return setupConfirmKeys
+		}
+	}
+	return m.viewKeys[m.currentView]
+}
+
 // GetCurrentView returns the current view name
 func (m *Model) GetCurrentView() string {
 	return m.currentView
@@ -223,4 +223,19 @@ func GetProductivityStatusStyle(isProductive bool) lipgloss.Style {
 
 func GetOptionStyle() lipgloss.Style {
 	return GetSetupOptionStyle()
+}
+
+// Modal window styles (windowmanager.go / windows.go)
+func GetModalStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorPrimary)).
+		Padding(1, 2)
+}
+
+func GetToastStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorSecondary)).
+		Padding(0, 1)
 }
\ No newline at end of file
@@ -5,8 +5,35 @@ import (
 	"strings"
 	"time"
 
+	"github.com/varadanvk/snitch/src/config"
+	"github.com/varadanvk/snitch/src/core"
 )
 
+// timelineBuckets are the bucket granularities viewTimeline cycles through
+// with the 'g' key.
+var timelineBuckets = []time.Duration{time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// timelineMinScores are the minimum-productivity-score thresholds
+// viewTimeline cycles through with the 't' key.
+var timelineMinScores = []float64{0, 0.3, 0.6, 0.8}
+
+// timelineWindow is how far back viewTimeline queries, regardless of bucket
+// granularity.
+const timelineWindow = 7 * 24 * time.Hour
+
+// viewHelp renders the full-screen keybinding overlay for the current view,
+// toggled by the '?' hotkey (see keys.go/ActiveKeys). Any of '?'/esc/b/q
+// closes it, handled in app.go's Update.
+func (m *Model) viewHelp() string {
+	headerStyle := GetHeaderStyle()
+
+	s := headerStyle.Render("KEYBINDINGS") + "\n\n"
+	m.help.ShowAll = true
+	s += m.help.View(m.ActiveKeys())
+	s += "\n\nPress '?', 'esc' or 'q' to close"
+	return s
+}
+
 // viewMain renders the main menu view
 func (m *Model) viewMain() string {
 	// Styles
@@ -60,18 +87,29 @@ func (m *Model) viewMain() string {
 		}
 	}
 
-	s += statusStyle.Render("\n\nPress q to quit, ↑/↓ to navigate, enter to select")
+	s += statusStyle.Render("\n\n" + m.help.View(m.ActiveKeys()))
 
 	return s
 }
 
-// viewActivity renders the activity log view
+// viewActivity renders the activity log view, with the filter bar ('/') and
+// status-cycle ('s') line from filter.go above the table.
 func (m *Model) viewActivity() string {
 	headerStyle := GetHeaderStyle()
+	labelStyle := GetLabelStyle()
 
 	s := headerStyle.Render("RECENT ACTIVITY LOG") + "\n\n"
+
+	if m.filterActive {
+		s += "Filter: " + m.filterInput.View() + "\n"
+	} else if m.filters.query != "" {
+		s += labelStyle.Render(fmt.Sprintf("Filter: %s", m.filters.query)) + "\n"
+	}
+	s += labelStyle.Render(fmt.Sprintf("Status: %s ('s' to cycle)  %d/%d shown",
+		m.filters.status, m.filters.shown, m.filters.total)) + "\n\n"
+
 	s += m.activityTable.View() + "\n"
-	s += "\nPress 'enter' to view details, 'b' to go back, 'q' to quit, ↑/↓ to navigate"
+	s += "\n" + m.help.View(m.ActiveKeys())
 	return s
 }
 
@@ -132,6 +170,11 @@ func (m *Model) viewActivityDetail() string {
 	s += labelStyle.Render("Duration:") + "\n"
 	s += valueStyle.Render(fmt.Sprintf("%d seconds", activity.Duration)) + "\n\n"
 
+	if activity.Source != "" {
+		s += labelStyle.Render("Analyzed By:") + "\n"
+		s += valueStyle.Render(strings.Title(activity.Source)) + "\n\n"
+	}
+
 	// Add some analysis context
 	s += labelStyle.Render("Analysis Context:") + "\n"
 	if activity.IsProductive {
@@ -140,10 +183,80 @@ func (m *Model) viewActivityDetail() string {
 		s += valueStyle.Render("[WARNING] This activity may be distracting from your main goals") + "\n"
 	}
 
+	// Show tools the agentic analyzer consulted before reaching this
+	// verdict, if any (most backends leave ToolTrace empty).
+	if len(activity.ToolTrace) > 0 {
+		s += "\n" + labelStyle.Render("Tool Trace:") + "\n"
+		for _, call := range activity.ToolTrace {
+			s += valueStyle.Render(fmt.Sprintf("- %s(%s) -> %s", call.Name, call.Arguments, call.Result)) + "\n"
+		}
+	}
+
 	s += "\n\nPress 'b' to go back, 'q' to quit"
 	return s
 }
 
+// viewSessions renders the session history list, one row per past
+// StartMonitoring/StopMonitoring run.
+func (m *Model) viewSessions() string {
+	headerStyle := GetHeaderStyle()
+	labelStyle := GetLabelStyle()
+
+	s := headerStyle.Render("SESSION HISTORY") + "\n\n"
+
+	if len(m.sessions) == 0 {
+		s += labelStyle.Render("No past sessions yet - Stop Monitoring to record one.") + "\n\n"
+	} else {
+		s += m.sessionTable.View() + "\n"
+	}
+
+	s += "\n" + m.help.View(m.ActiveKeys())
+	return s
+}
+
+// viewSessionDetail renders a past session's summary plus its activities,
+// reusing activityTable (already populated by updateSessionActivityTable)
+// the same way the activity log view does.
+func (m *Model) viewSessionDetail() string {
+	if m.selectedSession == nil {
+		return "No session selected"
+	}
+
+	headerStyle := GetHeaderStyle()
+	labelStyle := GetLabelStyle()
+	valueStyle := GetValueStyle()
+
+	session := m.selectedSession
+	s := headerStyle.Render("SESSION DETAILS") + "\n\n"
+
+	s += labelStyle.Render("Start:") + "\n"
+	s += valueStyle.Render(session.Start.Format("Monday, January 2, 2006 at 3:04:05 PM")) + "\n\n"
+
+	s += labelStyle.Render("Duration:") + "\n"
+	s += valueStyle.Render(session.Duration().Round(time.Second).String()) + "\n\n"
+
+	s += labelStyle.Render("Task:") + "\n"
+	task := session.Task
+	if task == "" {
+		task = "-"
+	}
+	s += valueStyle.Render(task) + "\n\n"
+
+	s += labelStyle.Render("Productivity:") + "\n"
+	s += valueStyle.Render(fmt.Sprintf("%.1f%%", session.ProductivityRate*100)) + "\n\n"
+
+	if session.TotalTokens > 0 {
+		s += labelStyle.Render("Groq Tokens:") + "\n"
+		s += valueStyle.Render(fmt.Sprintf("%d (%d prompt, %d completion)", session.TotalTokens, session.PromptTokens, session.CompletionTokens)) + "\n\n"
+	}
+
+	s += labelStyle.Render("Activities:") + "\n"
+	s += m.activityTable.View() + "\n"
+
+	s += "\n" + m.help.View(m.ActiveKeys())
+	return s
+}
+
 // viewStats renders the productivity statistics view
 func (m *Model) viewStats() string {
 	headerStyle := GetHeaderStyle()
@@ -190,6 +303,161 @@ func (m *Model) viewStats() string {
 		}
 	}
 
+	// Token usage (Groq backend only)
+	if tokenStats, ok := m.core.GetTokenStats(); ok {
+		s += "\n" + statStyle.Render("Groq Token Usage (session):") + "\n"
+		s += statStyle.Render(fmt.Sprintf("  Prompt: %d | Completion: %d | Total: %d (%d requests)",
+			tokenStats.PromptTokens, tokenStats.CompletionTokens, tokenStats.TotalTokens, tokenStats.Requests)) + "\n"
+	}
+
+	s += "\n\n" + m.help.View(m.ActiveKeys())
+	return s
+}
+
+// timelineApps returns the distinct applications seen in recent history,
+// used to build the 'a' filter cycle in viewTimeline/updateTimeline.
+func (m *Model) timelineApps() []string {
+	seen := make(map[string]bool)
+	var apps []string
+	for _, activity := range m.core.GetRecentActivities(200) {
+		if !seen[activity.Application] {
+			seen[activity.Application] = true
+			apps = append(apps, activity.Application)
+		}
+	}
+	return apps
+}
+
+// timelineCategories returns the distinct categories seen in recent
+// history, used to build the 'c' filter cycle in viewTimeline/updateTimeline.
+func (m *Model) timelineCategories() []string {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, activity := range m.core.GetRecentActivities(200) {
+		if !seen[activity.Category] {
+			seen[activity.Category] = true
+			categories = append(categories, activity.Category)
+		}
+	}
+	return categories
+}
+
+// timelineFilter builds the core.Filter for the currently selected
+// application/category/minimum-score cycle positions.
+func (m *Model) timelineFilter() core.Filter {
+	var filter core.Filter
+	if m.timelineAppIdx > 0 {
+		if apps := m.timelineApps(); m.timelineAppIdx-1 < len(apps) {
+			filter.Application = apps[m.timelineAppIdx-1]
+		}
+	}
+	if m.timelineCatIdx > 0 {
+		if categories := m.timelineCategories(); m.timelineCatIdx-1 < len(categories) {
+			filter.Category = categories[m.timelineCatIdx-1]
+		}
+	}
+	filter.MinProductivityScore = timelineMinScores[m.timelineMinScoreIdx%len(timelineMinScores)]
+	return filter
+}
+
+// viewTimeline renders a bucketed productivity histogram over the last
+// timelineWindow, with application/category/minimum-score filters
+// cyclable via the 'a'/'c'/'t' keys and bucket granularity via 'g'.
+func (m *Model) viewTimeline() string {
+	headerStyle := GetHeaderStyle()
+	statStyle := GetStatStyle()
+	labelStyle := GetLabelStyle()
+
+	s := headerStyle.Render("PRODUCTIVITY TIMELINE") + "\n\n"
+
+	bucket := timelineBuckets[m.timelineBucketIdx%len(timelineBuckets)]
+	filter := m.timelineFilter()
+	end := time.Now()
+	start := end.Add(-timelineWindow)
+
+	timeline, err := m.core.QueryTimeline(start, end, bucket, filter)
+	if err != nil {
+		s += labelStyle.Render(fmt.Sprintf("Timeline unavailable: %v", err)) + "\n"
+		s += "\n\nPress 'b' to go back, 'q' to quit"
+		return s
+	}
+
+	app := "all"
+	if filter.Application != "" {
+		app = filter.Application
+	}
+	category := "all"
+	if filter.Category != "" {
+		category = filter.Category
+	}
+	s += labelStyle.Render(fmt.Sprintf(
+		"Bucket: %s (g)  App: %s (a)  Category: %s (c)  Min score: %.1f (t)",
+		bucketLabel(bucket), app, category, filter.MinProductivityScore,
+	)) + "\n\n"
+
+	for _, b := range timeline.Buckets {
+		if b.Count == 0 {
+			continue
+		}
+		barWidth := int(b.ProductivityRate * 30)
+		bar := strings.Repeat("#", barWidth) + strings.Repeat(".", 30-barWidth)
+		s += statStyle.Render(fmt.Sprintf("%s  %s %.0f%% (%d)",
+			b.Start.Format(bucketTimeFormat(bucket)), bar, b.ProductivityRate*100, b.Count)) + "\n"
+	}
+
+	s += "\n" + statStyle.Render(fmt.Sprintf("Total activities: %d", timeline.TotalCount)) + "\n"
+
+	s += "\n\nPress 'b' to go back, 'q' to quit"
+	return s
+}
+
+// bucketLabel returns a short human-readable name for a timeline bucket
+// duration ("hour", "day", "week").
+func bucketLabel(bucket time.Duration) string {
+	switch bucket {
+	case 24 * time.Hour:
+		return "day"
+	case 7 * 24 * time.Hour:
+		return "week"
+	default:
+		return "hour"
+	}
+}
+
+// bucketTimeFormat returns the time.Format layout matching a bucket
+// duration's granularity.
+func bucketTimeFormat(bucket time.Duration) string {
+	switch bucket {
+	case 24 * time.Hour, 7 * 24 * time.Hour:
+		return "Jan 2"
+	default:
+		return "Jan 2 15:04"
+	}
+}
+
+// viewClusters renders the activity clusters discovered by core/cluster:
+// recurring activity types found by grouping embeddings rather than the
+// raw free-text activity description, with a representative example and
+// aggregate productivity score for each.
+func (m *Model) viewClusters() string {
+	headerStyle := GetHeaderStyle()
+	statStyle := GetStatStyle()
+	labelStyle := GetLabelStyle()
+
+	s := headerStyle.Render("ACTIVITY CLUSTERS") + "\n\n"
+
+	clusters := m.core.GetClusters()
+	if len(clusters) == 0 {
+		s += labelStyle.Render("No clusters discovered yet - keep monitoring to build some up.") + "\n"
+		s += "\n\nPress 'b' to go back, 'q' to quit"
+		return s
+	}
+
+	for _, c := range clusters {
+		s += statStyle.Render(fmt.Sprintf("Cluster %d: \"%s\" (%d activities, %.0f%% productive)",
+			c.ID, c.Representative, c.Count, c.AvgProductivity()*100)) + "\n"
+	}
+
 	s += "\n\nPress 'b' to go back, 'q' to quit"
 	return s
 }
@@ -197,7 +465,7 @@ func (m *Model) viewStats() string {
 // viewTask renders the task input view
 func (m *Model) viewTask() string {
 	headerStyle := GetHeaderStyle()
-	labelStyle := GetLabelStyle() 
+	labelStyle := GetLabelStyle()
 	valueStyle := GetValueStyle()
 	successStyle := GetSuccessStyle()
 	warningStyle := GetWarningStyle()
@@ -219,7 +487,7 @@ func (m *Model) viewTask() string {
 	currentTask := m.core.GetCurrentTask()
 	if currentTask != "" {
 		s += labelStyle.Render("Current Task:") + "\n"
-		s += valueStyle.Render("Task: " + currentTask) + "\n\n"
+		s += valueStyle.Render("Task: "+currentTask) + "\n\n"
 	}
 
 	s += valueStyle.Render("Examples:") + "\n"
@@ -229,7 +497,7 @@ func (m *Model) viewTask() string {
 	s += valueStyle.Render("• Reviewing pull requests") + "\n"
 	s += valueStyle.Render("• Planning sprint for next week") + "\n\n"
 
-	s += "Press Enter to save, 'b' to go back, 'q' to quit"
+	s += m.help.View(m.ActiveKeys())
 	return s
 }
 
@@ -250,16 +518,29 @@ func (m *Model) viewSetup() string {
 
 		s += labelStyle.Render("Current Configuration:") + "\n"
 		backendStatus := cfg.AIBackend
-		if cfg.AIBackend == "groq" {
+		switch cfg.AIBackend {
+		case "groq":
 			if cfg.GroqAPIKey != "" {
 				backendStatus += " [CONFIGURED]"
 			} else {
 				backendStatus += " [MISSING KEY]"
 			}
-		} else if cfg.AIBackend == "ollama" {
+		case "ollama":
 			backendStatus += " (local)"
+		case "anthropic":
+			if cfg.AnthropicAPIKey != "" {
+				backendStatus += " [CONFIGURED]"
+			} else {
+				backendStatus += " [MISSING KEY]"
+			}
+		case "openai":
+			if cfg.OpenAIAPIKey != "" {
+				backendStatus += " [CONFIGURED]"
+			} else {
+				backendStatus += " [MISSING KEY]"
+			}
 		}
-		s += valueStyle.Render("Backend: " + strings.Title(backendStatus)) + "\n\n"
+		s += valueStyle.Render("Backend: "+strings.Title(backendStatus)) + "\n\n"
 
 		s += labelStyle.Render("Choose an AI backend:") + "\n\n"
 
@@ -273,13 +554,22 @@ func (m *Model) viewSetup() string {
 		s += valueStyle.Render("   • Complete privacy") + "\n"
 		s += valueStyle.Render("   • Requires Ollama installation") + "\n\n"
 
-		s += "Press 1 for Groq, 2 for Ollama, or 'b' to go back"
+		s += optionStyle.Render("3. Anthropic (Claude)") + "\n"
+		s += valueStyle.Render("   • Fast cloud-based AI") + "\n"
+		s += valueStyle.Render("   • Requires API key") + "\n\n"
+
+		s += optionStyle.Render("4. OpenAI (GPT-4o)") + "\n"
+		s += valueStyle.Render("   • Fast cloud-based AI") + "\n"
+		s += valueStyle.Render("   • Requires API key") + "\n\n"
+
+		s += m.help.View(m.ActiveKeys())
 		return s
 
 	case 1: // Enter API key
-		s := headerStyle.Render("GROQ API KEY SETUP") + "\n\n"
+		label, signupURL := setupKeyLabel(m.setupBackend)
+		s := headerStyle.Render(strings.ToUpper(label)+" API KEY SETUP") + "\n\n"
 
-		s += labelStyle.Render("Enter your Groq API key:") + "\n\n"
+		s += labelStyle.Render("Enter your "+label+" API key:") + "\n\n"
 
 		s += m.textInput.View() + "\n\n"
 
@@ -291,8 +581,10 @@ func (m *Model) viewSetup() string {
 			}
 		}
 
-		s += valueStyle.Render("Get your free API key at: https://console.groq.com/") + "\n"
-		s += valueStyle.Render("Press Enter to save, Esc to go back") + "\n"
+		if signupURL != "" {
+			s += valueStyle.Render("Get your API key at: "+signupURL) + "\n"
+		}
+		s += valueStyle.Render(m.help.View(m.ActiveKeys())) + "\n"
 
 		return s
 
@@ -304,13 +596,13 @@ func (m *Model) viewSetup() string {
 		}
 
 		s += labelStyle.Render("Current Configuration:") + "\n"
-		s += valueStyle.Render("Backend: " + strings.Title(cfg.AIBackend)) + "\n"
-		if cfg.AIBackend == "groq" && cfg.GroqAPIKey != "" {
-			s += valueStyle.Render("API Key: " + cfg.GroqAPIKey[:8] + "..." + cfg.GroqAPIKey[len(cfg.GroqAPIKey)-4:]) + "\n"
+		s += valueStyle.Render("Backend: "+strings.Title(cfg.AIBackend)) + "\n"
+		if apiKey := activeBackendAPIKey(cfg); apiKey != "" {
+			s += valueStyle.Render("API Key: "+apiKey[:8]+"..."+apiKey[len(apiKey)-4:]) + "\n"
 		}
 		s += valueStyle.Render("Status: Ready for AI-powered analysis!") + "\n\n"
 
-		s += "Press Enter or 'b' to return to main menu"
+		s += m.help.View(m.ActiveKeys())
 		return s
 
 	default:
@@ -318,6 +610,34 @@ func (m *Model) viewSetup() string {
 	}
 }
 
+// setupKeyLabel returns the display name and signup URL for a backend name
+// ("groq", "anthropic", "openai") entered via the step-1 API key prompt.
+func setupKeyLabel(backend string) (label, signupURL string) {
+	switch backend {
+	case "anthropic":
+		return "Anthropic", "https://console.anthropic.com/"
+	case "openai":
+		return "OpenAI", "https://platform.openai.com/api-keys"
+	default:
+		return "Groq", "https://console.groq.com/"
+	}
+}
+
+// activeBackendAPIKey returns the API key configured for cfg.AIBackend, or
+// "" for backends with no key (Ollama) or none configured yet.
+func activeBackendAPIKey(cfg *config.Config) string {
+	switch cfg.AIBackend {
+	case "groq":
+		return cfg.GroqAPIKey
+	case "anthropic":
+		return cfg.AnthropicAPIKey
+	case "openai":
+		return cfg.OpenAIAPIKey
+	default:
+		return ""
+	}
+}
+
 // viewSettings renders the settings view
 func (m *Model) viewSettings() string {
 	headerStyle := GetHeaderStyle()
@@ -345,7 +665,7 @@ func (m *Model) viewSettings() string {
 			}
 		}
 
-		s += valueStyle.Render("Press Enter to save, Esc to cancel") + "\n"
+		s += valueStyle.Render(m.help.View(m.ActiveKeys())) + "\n"
 	} else {
 		// Show settings menu
 		for i, setting := range m.settingsItems {
@@ -371,6 +691,14 @@ func (m *Model) viewSettings() string {
 				currentValue = fmt.Sprintf("%d apps", len(cfg.ProductiveApps))
 			case 5:
 				currentValue = fmt.Sprintf("%d apps", len(cfg.DistractingApps))
+			case 6:
+				currentValue = cfg.CalDAVURL
+			case 7:
+				currentValue = cfg.CalDAVUsername
+			case 8:
+				if cfg.CalDAVPassword != "" {
+					currentValue = "********"
+				}
 			}
 
 			s += style.Render(fmt.Sprintf("%s %s: %s", cursor, setting, currentValue)) + "\n"
@@ -399,8 +727,8 @@ func (m *Model) viewSettings() string {
 			}
 		}
 
-		s += "\n\nPress Enter to edit, ↑/↓ to navigate, 'b' to go back, 'q' to quit"
+		s += "\n\n" + m.help.View(m.ActiveKeys())
 	}
 
 	return s
-}
\ No newline at end of file
+}
@@ -0,0 +1,184 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// View is a single screen in the TUI's navigation stack. Update receives
+// messages exactly like a tea.Model and returns the View that should now sit
+// on top of the stack: itself for no navigation, a newly constructed View to
+// push, or the View beneath it to pop back to. View and Title render the
+// screen's body and the shared header's title, respectively.
+type View interface {
+	Update(msg tea.Msg) (View, tea.Cmd)
+	View() string
+	Title() string
+}
+
+// ViewStack is a navigation stack of Views. Push/Pop/Replace are the only
+// ways the active screen changes, so a confirm dialog, an error toast, or a
+// future session-report screen can all be layered on without the root Model
+// knowing anything about them by name.
+type ViewStack struct {
+	views []View
+}
+
+// NewViewStack creates a stack with root as its only (bottom) view. The
+// root is never popped.
+func NewViewStack(root View) *ViewStack {
+	return &ViewStack{views: []View{root}}
+}
+
+// Top returns the view currently on top of the stack, or nil if empty.
+func (s *ViewStack) Top() View {
+	if len(s.views) == 0 {
+		return nil
+	}
+	return s.views[len(s.views)-1]
+}
+
+// Push adds v on top of the stack, making it the active view.
+func (s *ViewStack) Push(v View) {
+	s.views = append(s.views, v)
+}
+
+// Pop removes the top view and returns the new top. Popping the root is a
+// no-op since there's nothing left to fall back to.
+func (s *ViewStack) Pop() View {
+	if len(s.views) > 1 {
+		s.views = s.views[:len(s.views)-1]
+	}
+	return s.Top()
+}
+
+// Replace swaps the top view for v without growing the stack, e.g. moving
+// between wizard steps that shouldn't each get their own Esc level.
+func (s *ViewStack) Replace(v View) {
+	if len(s.views) == 0 {
+		s.views = []View{v}
+		return
+	}
+	s.views[len(s.views)-1] = v
+}
+
+// Len reports the current stack depth.
+func (s *ViewStack) Len() int {
+	return len(s.views)
+}
+
+// legacyView adapts one of Model's existing updateX/viewX method pairs into
+// the View interface. The per-screen logic in handlers.go/views.go still
+// does all the work; legacyView just watches m.currentView for the name
+// those methods already set and turns a change into the matching
+// Push/Pop/Replace, so back-navigation falls out of the existing "esc
+// sets currentView to the parent screen" code without a central switch.
+type legacyView struct {
+	m    *Model
+	name string
+}
+
+func newLegacyView(m *Model, name string) *legacyView {
+	return &legacyView{m: m, name: name}
+}
+
+func (v *legacyView) Title() string {
+	return legacyViewTitles[v.name]
+}
+
+func (v *legacyView) View() string {
+	return legacyViewRenderers[v.name](v.m)
+}
+
+func (v *legacyView) Update(msg tea.Msg) (View, tea.Cmd) {
+	var cmd tea.Cmd
+	switch typed := msg.(type) {
+	case tea.KeyMsg:
+		_, cmd = legacyViewUpdaters[v.name](v.m, typed)
+	case tea.MouseMsg:
+		_, cmd = v.m.handleTableClick(typed)
+	default:
+		return v, nil
+	}
+
+	next := v.m.currentView
+	if next == v.name {
+		return v, cmd
+	}
+
+	switch {
+	case viewDepth(next) > viewDepth(v.name):
+		pushed := newLegacyView(v.m, next)
+		v.m.viewStack.Push(pushed)
+		return pushed, cmd
+	case viewDepth(next) < viewDepth(v.name):
+		return v.m.viewStack.Pop(), cmd
+	default:
+		replaced := newLegacyView(v.m, next)
+		v.m.viewStack.Replace(replaced)
+		return replaced, cmd
+	}
+}
+
+// viewDepth orders screens by how many Esc presses it takes to reach main,
+// which is all ViewStack needs to tell a Push from a Pop: main is the root,
+// activity_detail is one level deeper than everything reachable directly
+// from main.
+func viewDepth(name string) int {
+	switch name {
+	case "main":
+		return 0
+	case "activity_detail", "session_detail":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// legacyViewUpdaters maps a currentView name to the Model method that
+// already implements its key handling.
+var legacyViewUpdaters = map[string]func(*Model, tea.KeyMsg) (tea.Model, tea.Cmd){
+	"main":            (*Model).updateMain,
+	"activity":        (*Model).updateActivity,
+	"activity_detail": (*Model).updateActivityDetail,
+	"settings":        (*Model).updateSettings,
+	"setup":           (*Model).updateSetup,
+	"task":            (*Model).updateTask,
+	"stats":           (*Model).updateStats,
+	"timeline":        (*Model).updateTimeline,
+	"clusters":        (*Model).updateClusters,
+	"analysis":        (*Model).updateAnalysis,
+	"sessions":        (*Model).updateSessions,
+	"session_detail":  (*Model).updateSessionDetail,
+}
+
+// legacyViewRenderers maps a currentView name to the Model method that
+// already renders it.
+var legacyViewRenderers = map[string]func(*Model) string{
+	"main":            (*Model).viewMain,
+	"activity":        (*Model).viewActivity,
+	"activity_detail": (*Model).viewActivityDetail,
+	"settings":        (*Model).viewSettings,
+	"setup":           (*Model).viewSetup,
+	"task":            (*Model).viewTask,
+	"stats":           (*Model).viewStats,
+	"timeline":        (*Model).viewTimeline,
+	"clusters":        (*Model).viewClusters,
+	"analysis":        (*Model).viewAnalysis,
+	"sessions":        (*Model).viewSessions,
+	"session_detail":  (*Model).viewSessionDetail,
+}
+
+// legacyViewTitles gives each screen the header title the shared chrome
+// renders above its body.
+var legacyViewTitles = map[string]string{
+	"main":            "Snitch",
+	"activity":        "Activity Log",
+	"activity_detail": "Activity Details",
+	"settings":        "Settings",
+	"setup":           "AI Setup",
+	"task":            "Set Current Task",
+	"stats":           "Productivity Stats",
+	"timeline":        "Timeline",
+	"clusters":        "Clusters",
+	"analysis":        "Live Analysis",
+	"sessions":        "Session History",
+	"session_detail":  "Session Details",
+}
@@ -0,0 +1,122 @@
+// Package windowmanager maintains a stack of overlay windows rendered on
+// top of a host TUI's base view, analogous to how viewstack.go maintains a
+// stack of full screens. A Window may be Blocking (a confirmation dialog or
+// a quick-input prompt, which must intercept every key while it's on top)
+// or non-blocking (a toast, which renders over the base view without
+// stealing focus and is driven by its own tea.Cmd instead of keystrokes).
+package windowmanager
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Window is one entry in a WM stack.
+type Window interface {
+	// Update handles a message and returns the (possibly replaced) window
+	// plus any tea.Cmd it wants scheduled. Returning (nil, cmd) tells the WM
+	// to pop this window off the stack.
+	Update(msg tea.Msg) (Window, tea.Cmd)
+	// View renders the window's own content; the host positions/styles it.
+	View() string
+	// Blocking reports whether this window should intercept key events
+	// instead of letting them reach the screen underneath.
+	Blocking() bool
+}
+
+// WM is a stack of overlay windows. The top of the stack is the
+// most-recently pushed window.
+type WM struct {
+	stack []Window
+}
+
+// New returns an empty WM.
+func New() *WM {
+	return &WM{}
+}
+
+// Push adds w to the top of the stack.
+func (wm *WM) Push(w Window) {
+	wm.stack = append(wm.stack, w)
+}
+
+// Pop removes and returns the top window, or nil if the stack is empty.
+func (wm *WM) Pop() Window {
+	if len(wm.stack) == 0 {
+		return nil
+	}
+	top := wm.stack[len(wm.stack)-1]
+	wm.stack = wm.stack[:len(wm.stack)-1]
+	return top
+}
+
+// Top returns the top window without removing it, or nil if the stack is
+// empty.
+func (wm *WM) Top() Window {
+	if len(wm.stack) == 0 {
+		return nil
+	}
+	return wm.stack[len(wm.stack)-1]
+}
+
+// Active reports whether any window is on the stack.
+func (wm *WM) Active() bool {
+	return len(wm.stack) > 0
+}
+
+// Blocking reports whether the top window is blocking. False if the stack
+// is empty.
+func (wm *WM) Blocking() bool {
+	top := wm.Top()
+	return top != nil && top.Blocking()
+}
+
+// UpdateTop dispatches msg to the top window only and pops it if it returns
+// nil. This is what the host should call for key events, since only the top
+// window should ever intercept the keyboard. No-op if the stack is empty.
+func (wm *WM) UpdateTop(msg tea.Msg) tea.Cmd {
+	if len(wm.stack) == 0 {
+		return nil
+	}
+	idx := len(wm.stack) - 1
+	next, cmd := wm.stack[idx].Update(msg)
+	if next == nil {
+		wm.stack = wm.stack[:idx]
+	} else {
+		wm.stack[idx] = next
+	}
+	return cmd
+}
+
+// Broadcast dispatches msg to every window on the stack (e.g. a tick driving
+// a toast's auto-dismiss timer), popping any that return nil, and batches
+// the resulting commands.
+func (wm *WM) Broadcast(msg tea.Msg) tea.Cmd {
+	if len(wm.stack) == 0 {
+		return nil
+	}
+	var cmds []tea.Cmd
+	kept := wm.stack[:0]
+	for _, w := range wm.stack {
+		next, cmd := w.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		if next != nil {
+			kept = append(kept, next)
+		}
+	}
+	wm.stack = kept
+	return tea.Batch(cmds...)
+}
+
+// View renders every window on the stack bottom-to-top, joined with blank
+// lines, for the host to compose with the base view. Empty string if the
+// stack is empty.
+func (wm *WM) View() string {
+	if len(wm.stack) == 0 {
+		return ""
+	}
+	out := wm.stack[0].View()
+	for _, w := range wm.stack[1:] {
+		out += "\n\n" + w.View()
+	}
+	return out
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/varadanvk/snitch/src/ui/windowmanager"
+)
+
+// confirmWindow is a generic blocking yes/no dialog. onYes runs when the
+// user accepts; declining (or esc) just pops the window with no side
+// effect. Neither closes over anything windowmanager-specific, so it's
+// reusable for any "are you sure?" prompt, not just Stop Monitoring.
+type confirmWindow struct {
+	message string
+	onYes   func() tea.Cmd
+}
+
+func newConfirmWindow(message string, onYes func() tea.Cmd) *confirmWindow {
+	return &confirmWindow{message: message, onYes: onYes}
+}
+
+func (w *confirmWindow) Update(msg tea.Msg) (windowmanager.Window, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return w, nil
+	}
+	switch keyMsg.String() {
+	case "y", "enter":
+		return nil, w.onYes()
+	case "n", "esc", "ctrl+c":
+		return nil, nil
+	}
+	return w, nil
+}
+
+func (w *confirmWindow) View() string {
+	body := w.message + "\n\n[y]es   [n]o"
+	return GetModalStyle().Render(body)
+}
+
+func (w *confirmWindow) Blocking() bool {
+	return true
+}
+
+// toastTickMsg drives every open toastWindow's auto-dismiss timer,
+// independent of whatever the active view's own tick is doing (see
+// TickCmd, which only runs while monitoring). Started once in Init and
+// re-issued on every tick regardless of whether a toast is open.
+type toastTickMsg time.Time
+
+func toastTickCmd() tea.Cmd {
+	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
+		return toastTickMsg(t)
+	})
+}
+
+const toastTTL = 3 * time.Second
+
+// toastWindow is a non-blocking overlay that renders a message below the
+// base view until it expires. It never intercepts keys.
+type toastWindow struct {
+	message string
+	style   lipgloss.Style
+	expires time.Time
+}
+
+func newToastWindow(message string) *toastWindow {
+	style := GetToastStyle()
+	if strings.HasPrefix(message, "[ERROR]") {
+		style = style.BorderForeground(lipgloss.Color(ColorError))
+	} else if strings.HasPrefix(message, "[SUCCESS]") {
+		style = style.BorderForeground(lipgloss.Color(ColorSuccess))
+	}
+	return &toastWindow{message: message, style: style, expires: time.Now().Add(toastTTL)}
+}
+
+func (w *toastWindow) Update(msg tea.Msg) (windowmanager.Window, tea.Cmd) {
+	if tick, ok := msg.(toastTickMsg); ok {
+		if time.Time(tick).After(w.expires) {
+			return nil, nil
+		}
+	}
+	return w, nil
+}
+
+func (w *toastWindow) View() string {
+	return w.style.Render(w.message)
+}
+
+func (w *toastWindow) Blocking() bool {
+	return false
+}
+
+// pushToast opens a toastWindow for message, replacing the legacy
+// taskMessage/settingsMessage/setupMessage behavior of only showing until
+// the next render: the toast now stays up for toastTTL regardless of what
+// else redraws in the meantime.
+func (m *Model) pushToast(message string) {
+	if message == "" {
+		return
+	}
+	m.wm.Push(newToastWindow(message))
+	m.needsRedraw = true
+}
+
+// setTaskMessage sets taskMessage (still read directly by viewTask) and
+// surfaces the same text as a toast.
+func (m *Model) setTaskMessage(message string) {
+	m.taskMessage = message
+	m.pushToast(message)
+}
+
+// setSettingsMessage sets settingsMessage (still read directly by
+// viewSettings) and surfaces the same text as a toast.
+func (m *Model) setSettingsMessage(message string) {
+	m.settingsMessage = message
+	m.pushToast(message)
+}
+
+// setSetupMessage sets setupMessage (still read directly by viewSetup) and
+// surfaces the same text as a toast.
+func (m *Model) setSetupMessage(message string) {
+	m.setupMessage = message
+	m.pushToast(message)
+}
+
+// quickTaskWindow is the floating "Set Task" prompt opened with
+// keyQuickTask from any screen. It closes over *Model directly (like
+// legacyView does) so it can apply the task straight to m.core without a
+// generic callback plumbing.
+type quickTaskWindow struct {
+	m     *Model
+	input textinput.Model
+}
+
+func newQuickTaskWindow(m *Model) *quickTaskWindow {
+	ti := textinput.New()
+	ti.Placeholder = "Enter your current task..."
+	ti.CharLimit = 200
+	ti.Width = 60
+	ti.SetValue(m.core.GetCurrentTask())
+	ti.Focus()
+	return &quickTaskWindow{m: m, input: ti}
+}
+
+func (w *quickTaskWindow) Update(msg tea.Msg) (windowmanager.Window, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return w, nil
+	}
+	switch keyMsg.String() {
+	case "esc", "ctrl+c":
+		return nil, nil
+	case "enter":
+		task := strings.TrimSpace(w.input.Value())
+		if task == "" {
+			return w, nil
+		}
+		w.m.core.SetCurrentTask(task)
+		w.m.taskInput.SetValue(task)
+		w.m.pushToast("[SUCCESS] Current task set successfully!")
+		return nil, nil
+	}
+	var cmd tea.Cmd
+	w.input, cmd = w.input.Update(msg)
+	return w, cmd
+}
+
+func (w *quickTaskWindow) View() string {
+	body := "Set Current Task\n\n" + w.input.View() + "\n\n[enter] confirm   [esc] cancel"
+	return GetModalStyle().Render(body)
+}
+
+func (w *quickTaskWindow) Blocking() bool {
+	return true
+}